@@ -2,41 +2,132 @@ package api
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
 )
 
-func Binding(r *gin.Engine, d *rest.RestDelivery) {
+// Binding registers the routes, every one but the probes requires a scope
+// from the principal set by middleware.Auth. The changes are also authorized
+// by authz unless it is nil. Each route is scoped to the tenant of the
+// X-Tenant header, or of the path under /tenants/:tenant, and reads at the
+// revision of the X-Revision header at least. Path parameters are validated
+// like the identifiers of the bodies.
+func Binding(r *gin.Engine, d *rest.RestDelivery,
+	authz *middleware.Authorizer) {
+	r.Use(middleware.Tenant(), middleware.Revision(), d.ValidateParams)
+	routes(&r.RouterGroup, d, authz)
+	routes(r.Group("/tenants/:tenant"), d, authz)
+}
+
+func routes(r *gin.RouterGroup, d *rest.RestDelivery,
+	authz *middleware.Authorizer) {
+	read := middleware.RequireScope(middleware.ReadScope)
+	write := middleware.RequireScope(middleware.WriteScope)
+	admin := middleware.RequireScope(middleware.AdminScope)
+	manage := func(object func(c *gin.Context) string) gin.HandlerFunc {
+		if authz == nil {
+			return func(c *gin.Context) { c.Next() }
+		}
+		return authz.Manage(object)
+	}
+	system := manage(func(c *gin.Context) string {
+		return middleware.SystemObject
+	})
+	user := manage(func(c *gin.Context) string {
+		return "user/" + c.Param("name")
+	})
+	role := manage(func(c *gin.Context) string {
+		return "role/" + c.Param("name")
+	})
+	object := manage(func(c *gin.Context) string {
+		return middleware.TargetObject(c.Param("ns"), c.Param("name"))
+	})
+	// a permission or deny also changes who can reach its object
+	target := manage(func(c *gin.Context) string {
+		var requestBody struct {
+			ObjNs   string `json:"obj_ns"`
+			ObjName string `json:"obj_name"`
+		}
+		_ = c.ShouldBindBodyWith(&requestBody, binding.JSON)
+		return middleware.TargetObject(requestBody.ObjNs, requestBody.ObjName)
+	})
+	// the inherited role is changed as much as the inheriting one
+	inherited := manage(func(c *gin.Context) string {
+		var requestBody struct {
+			Name string `json:"name"`
+		}
+		_ = c.ShouldBindBodyWith(&requestBody, binding.JSON)
+		return "role/" + requestBody.Name
+	})
+	// the members of a role are managed by the managers of the role
+	member := manage(func(c *gin.Context) string {
+		var requestBody struct {
+			RoleName string `json:"role_name"`
+		}
+		_ = c.ShouldBindBodyWith(&requestBody, binding.JSON)
+		return "role/" + requestBody.RoleName
+	})
+
 	r.GET("/ping", d.Ping)
 	r.GET("/healthy", d.Healthy)
+	r.POST("/batch", write, system, d.ApplyOperations)
+	r.POST("/check/bulk", read, d.BulkCheck)
+	r.GET("/schema", read, d.GetSchema)
+	r.PUT("/schema", admin, system, d.SetSchema)
+	r.GET("/watch", read, d.Watch)
+	r.GET("/watch/poll", read, d.PollWatch)
 	// r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	userR := r.Group("/user")
 	{
-		userR.DELETE("/:name", d.DeleteUser)
-		userR.GET("/:name/permission", d.UserGetPermissions)
-		userR.GET("/:name/role", d.UserGetRoles)
-		userR.GET("/:name/check/:rel/:objns/:objname", d.UserCheck)
-		userR.POST("/:name/permission", d.UserAddPermission)
-		userR.DELETE("/:name/permission", d.UserRemovePermission)
-		userR.POST("/:name/role", d.UserAddRole)
-		userR.DELETE("/:name/role", d.UserRemoveRole)
+		userR.DELETE("/:name", admin, user, d.DeleteUser)
+		userR.GET("/:name/permission", read, d.UserGetPermissions)
+		userR.GET("/:name/role", read, d.UserGetRoles)
+		userR.GET("/:name/check/:rel/:objns/:objname", read, d.UserCheck)
+		userR.GET("/:name/explain/:rel/:objns/:objname", read, d.UserExplain)
+		userR.POST("/:name/permission", write, user, target,
+			d.UserAddPermission)
+		userR.DELETE("/:name/permission", write, user, target,
+			d.UserRemovePermission)
+		userR.POST("/:name/deny", write, user, target, d.UserAddDeny)
+		userR.DELETE("/:name/deny", write, user, target,
+			d.UserRemoveDeny)
+		userR.POST("/:name/role", write, member, d.UserAddRole)
+		userR.DELETE("/:name/role", write, member, d.UserRemoveRole)
 	}
 	roleR := r.Group("/role")
 	{
-		roleR.DELETE("/:name", d.DeleteRole)
-		roleR.GET("/:name/user", d.RoleGetUsers)
-		roleR.GET("/:name/permission", d.RoleGetPermissions)
-		roleR.POST("/:name/permission", d.RoleAddPermission)
-		roleR.DELETE("/:name/permission", d.RoleRemovePermission)
-		roleR.POST("/:name/inherit", d.RoleInheritRole)
-		roleR.DELETE("/:name/inherit", d.RoleUnInheritRole)
-		roleR.GET("/:name/child", d.RoleGetChildRole)
-		roleR.GET("/:name/parent", d.RoleGetParentRole)
+		roleR.DELETE("/:name", admin, role, d.DeleteRole)
+		roleR.GET("/:name/user", read, d.RoleGetUsers)
+		roleR.GET("/:name/permission", read, d.RoleGetPermissions)
+		roleR.POST("/:name/permission", write, role, target,
+			d.RoleAddPermission)
+		roleR.DELETE("/:name/permission", write, role, target,
+			d.RoleRemovePermission)
+		roleR.POST("/:name/deny", write, role, target, d.RoleAddDeny)
+		roleR.DELETE("/:name/deny", write, role, target,
+			d.RoleRemoveDeny)
+		roleR.POST("/:name/inherit", write, role, inherited,
+			d.RoleInheritRole)
+		roleR.DELETE("/:name/inherit", write, role, inherited,
+			d.RoleUnInheritRole)
+		roleR.GET("/:name/child", read, d.RoleGetChildRole)
+		roleR.GET("/:name/parent", read, d.RoleGetParentRole)
 	}
 	objectR := r.Group("/object")
 	{
-		objectR.DELETE("/:ns/:name", d.DeleteObject)
-		objectR.GET("/:ns/:name/role", d.WhichRoleHasPermission)
-		objectR.GET("/:ns/:name/user", d.WhichUserHasPermission)
+		objectR.DELETE("/:ns/:name", write, object, d.DeleteObject)
+		objectR.GET("/:ns/:name/role", read, d.WhichRoleHasPermission)
+		objectR.GET("/:ns/:name/user", read, d.WhichUserHasPermission)
+	}
+	adminR := r.Group("/admin", admin, system)
+	{
+		adminR.GET("/cycle", d.FindCycles)
+		adminR.GET("/cache", d.CacheStats)
+		adminR.POST("/closure/rebuild", d.RebuildClosure)
+		adminR.GET("/closure/verify", d.VerifyClosure)
+		adminR.GET("/edges", d.ExportTenant)
+		adminR.DELETE("/edges", d.PurgeTenant)
 	}
 }
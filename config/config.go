@@ -1,17 +1,243 @@
 package config
 
 import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
 	errors "github.com/rotisserie/eris"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
+// ReadConfig loads the settings from, in increasing order of precedence, the
+// defaults, the yaml file, RBAC_* environment variables and command-line
+// flags, then validates them.
 func ReadConfig() error {
-	viper.AddConfigPath("./config")
-	viper.SetConfigType("yaml")
+	return readConfig(os.Args[1:])
+}
+
+func readConfig(args []string) error {
+	setDefaults()
 
+	flags := pflag.NewFlagSet("rbac-server", pflag.ContinueOnError)
+	configFile := flags.String("config", "", "path of the yaml config file")
+	flags.String("db", "", "repository backend, mongo or memory")
+	flags.String("server.addr", "", "listen address of the REST API")
+	flags.String("grpc.addr", "", "listen address of the gRPC API")
+	flags.String("schema.file", "", "yaml or json file of the namespace schema")
+	flags.String("graph.engine", "",
+		"traversal engine, bfs or mongo ($graphLookup)")
+	flags.Duration("reaper.interval", 0,
+		"how often expired edges are purged, 0 disables the reaper")
+	flags.String("mongo.uri", "", "MongoDB connection string")
+	flags.String("mongo.username", "", "MongoDB username")
+	flags.String("mongo.password", "", "MongoDB password")
+	flags.String("mongo.auth_source", "", "MongoDB authentication database")
+	flags.Duration("mongo.connect_timeout", 0, "MongoDB connect timeout")
+	flags.String("mongo.db", "", "MongoDB database name")
+	flags.String("mongo.collection", "", "MongoDB edge collection name")
+	flags.Bool("mongo.tls.enabled", false, "connect to MongoDB over TLS")
+	flags.String("mongo.tls.ca_file", "", "PEM file of the CA to trust")
+	flags.String("mongo.tls.cert_file", "", "PEM file of the client certificate")
+	flags.String("mongo.tls.key_file", "", "PEM file of the client key")
+	flags.Bool("mongo.tls.insecure_skip_verify", false,
+		"skip verifying the MongoDB certificate")
+	flags.Bool("cache.enabled", false,
+		"cache the checks and searches, only while this server makes every write")
+	flags.Int("cache.size", 0, "how many checks and searches are cached")
+	flags.Bool("closure.enabled", false,
+		"answer the checks of users from the materialized role closure")
+	flags.Bool("auth.enabled", false, "require credentials on the REST API")
+	flags.String("auth.hmac.secret", "", "shared secret of HS256 tokens")
+	flags.String("auth.jwks.file", "", "JWKS file of the RS256/ES256 token keys")
+	flags.String("auth.issuer", "", "required iss claim of the tokens")
+	flags.String("auth.audience", "", "required aud claim of the tokens")
+	flags.Bool("authz.enabled", false,
+		"authorize the changes with manage on rbac:<resource>")
+	flags.StringSlice("authz.super_admins", nil,
+		"subjects allowed to change everything, to bootstrap the grants")
+	if err := flags.Parse(args); err != nil {
+		return errors.Wrap(err, "parse flags")
+	}
+	// viper only takes a flag over the other sources when it was given
+	if err := viper.BindPFlags(flags); err != nil {
+		return errors.Wrap(err, "bind flags")
+	}
+
+	viper.SetEnvPrefix("rbac")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	if *configFile != "" {
+		viper.SetConfigFile(*configFile)
+	} else {
+		viper.AddConfigPath("./config")
+		viper.SetConfigType("yaml")
+	}
 	if err := viper.ReadInConfig(); err != nil {
-		return errors.New(err.Error())
+		var notFound viper.ConfigFileNotFoundError
+		// the default file is optional, an explicit one is not
+		if *configFile != "" || !errors.As(err, &notFound) {
+			return errors.New(err.Error())
+		}
+	}
+
+	return validate()
+}
+
+func setDefaults() {
+	viper.SetDefault("db", "mongo")
+	viper.SetDefault("server.addr", ":8081")
+	viper.SetDefault("grpc.addr", ":8082")
+	viper.SetDefault("graph.engine", "bfs")
+	viper.SetDefault("reaper.interval", time.Minute)
+	viper.SetDefault("mongo.uri", "mongodb://localhost:27017")
+	viper.SetDefault("mongo.connect_timeout", 10*time.Second)
+	viper.SetDefault("mongo.db", "rbac-server")
+	viper.SetDefault("mongo.collection", "edges")
+	viper.SetDefault("mongo.tls.enabled", false)
+	viper.SetDefault("mongo.tls.insecure_skip_verify", false)
+	viper.SetDefault("cache.enabled", false)
+	viper.SetDefault("cache.size", 10000)
+	viper.SetDefault("closure.enabled", false)
+	viper.SetDefault("auth.enabled", false)
+	viper.SetDefault("authz.enabled", false)
+}
+
+// validate checks every key and reports all the problems at once.
+func validate() error {
+	problems := []string{}
+	for _, key := range []string{"server.addr", "grpc.addr"} {
+		if _, _, err := net.SplitHostPort(viper.GetString(key)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid address %q",
+				key, viper.GetString(key)))
+		}
+	}
+
+	if path := viper.GetString("schema.file"); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			problems = append(problems, fmt.Sprintf("schema.file: %v", err))
+		}
+	}
+	if viper.GetBool("cache.enabled") && viper.GetInt("cache.size") <= 0 {
+		problems = append(problems, fmt.Sprintf(
+			"cache.size: must be positive, got %q",
+			viper.GetString("cache.size")))
+	}
+	if viper.GetDuration("reaper.interval") < 0 {
+		problems = append(problems, fmt.Sprintf(
+			"reaper.interval: must not be negative, got %q",
+			viper.GetString("reaper.interval")))
 	}
 
+	switch db := viper.GetString("db"); db {
+	case "memory":
+	case "mongo":
+		uri := viper.GetString("mongo.uri")
+		if !strings.HasPrefix(uri, "mongodb://") &&
+			!strings.HasPrefix(uri, "mongodb+srv://") {
+			problems = append(problems,
+				"mongo.uri: must start with mongodb:// or mongodb+srv://")
+		}
+		for _, key := range []string{"mongo.db", "mongo.collection"} {
+			if viper.GetString(key) == "" {
+				problems = append(problems, key+": missing")
+			}
+		}
+		if viper.GetDuration("mongo.connect_timeout") <= 0 {
+			problems = append(problems, fmt.Sprintf(
+				"mongo.connect_timeout: must be a positive duration, got %q",
+				viper.GetString("mongo.connect_timeout")))
+		}
+		if viper.GetString("mongo.password") != "" &&
+			viper.GetString("mongo.username") == "" {
+			problems = append(problems,
+				"mongo.username: missing while mongo.password is set")
+		}
+		if (viper.GetString("mongo.tls.cert_file") == "") !=
+			(viper.GetString("mongo.tls.key_file") == "") {
+			problems = append(problems,
+				"mongo.tls: cert_file and key_file must be set together")
+		}
+		for _, key := range []string{"mongo.tls.ca_file", "mongo.tls.cert_file",
+			"mongo.tls.key_file"} {
+			if path := viper.GetString(key); path != "" {
+				if _, err := os.Stat(path); err != nil {
+					problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+				}
+			}
+		}
+	default:
+		problems = append(problems, fmt.Sprintf(
+			"db: must be mongo or memory, got %q", db))
+	}
+	switch engine := viper.GetString("graph.engine"); engine {
+	case "bfs":
+	case "mongo":
+		if viper.GetString("db") != "mongo" {
+			problems = append(problems, "graph.engine: mongo requires db mongo")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf(
+			"graph.engine: must be bfs or mongo, got %q", engine))
+	}
+
+	if viper.GetBool("auth.enabled") {
+		problems = append(problems, validateAuth()...)
+	}
+	if viper.GetBool("authz.enabled") && !viper.GetBool("auth.enabled") {
+		problems = append(problems,
+			"authz.enabled: requires auth.enabled to know the caller")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid config:\n  - " +
+			strings.Join(problems, "\n  - "))
+	}
 	return nil
 }
+
+func validateAuth() []string {
+	problems := []string{}
+	var keys []struct {
+		Key     string   `mapstructure:"key"`
+		Subject string   `mapstructure:"subject"`
+		Scopes  []string `mapstructure:"scopes"`
+	}
+	if err := viper.UnmarshalKey("auth.api_keys", &keys); err != nil {
+		problems = append(problems, fmt.Sprintf("auth.api_keys: %v", err))
+	}
+	for i, k := range keys {
+		if k.Key == "" || k.Subject == "" {
+			problems = append(problems, fmt.Sprintf(
+				"auth.api_keys[%d]: key and subject are required", i))
+		}
+		for _, scope := range k.Scopes {
+			if scope != "read" && scope != "write" && scope != "admin" {
+				problems = append(problems, fmt.Sprintf(
+					"auth.api_keys[%d].scopes: must be read, write or admin, "+
+						"got %q", i, scope))
+			}
+		}
+	}
+
+	secret := viper.GetString("auth.hmac.secret")
+	if secret != "" && len(secret) < 32 {
+		problems = append(problems,
+			"auth.hmac.secret: must be at least 32 bytes long")
+	}
+	jwks := viper.GetString("auth.jwks.file")
+	if jwks != "" {
+		if _, err := os.Stat(jwks); err != nil {
+			problems = append(problems, fmt.Sprintf("auth.jwks.file: %v", err))
+		}
+	}
+	if len(keys) == 0 && secret == "" && jwks == "" {
+		problems = append(problems, "auth: enabled without api_keys, "+
+			"hmac.secret nor jwks.file")
+	}
+	return problems
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadConfigPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(
+		"mongo:\n  db: from-file\n  collection: from-file\n"), 0o644))
+	t.Setenv("RBAC_MONGO_COLLECTION", "from-env")
+	t.Setenv("RBAC_MONGO_DB", "from-env")
+
+	viper.Reset()
+	assert.NoError(t, readConfig([]string{"--config", file,
+		"--mongo.db", "from-flag"}))
+	assert.Equal(t, "from-flag", viper.GetString("mongo.db"))
+	assert.Equal(t, "from-env", viper.GetString("mongo.collection"))
+	assert.Equal(t, ":8081", viper.GetString("server.addr"))
+}
+
+func TestReadConfigValidation(t *testing.T) {
+	viper.Reset()
+	err := readConfig([]string{"--config", filepath.Join("..", "config",
+		"config.yaml"), "--mongo.uri", "localhost", "--server.addr", "8081",
+		"--mongo.password", "secret"})
+	assert.ErrorContains(t, err, "mongo.uri")
+	assert.ErrorContains(t, err, "server.addr")
+	assert.ErrorContains(t, err, "mongo.username")
+
+	viper.Reset()
+	err = readConfig([]string{"--config", filepath.Join("..", "config",
+		"config.yaml"), "--db", "memory", "--graph.engine", "mongo"})
+	assert.ErrorContains(t, err, "graph.engine: mongo requires db mongo")
+
+	viper.Reset()
+	err = readConfig([]string{"--config", "missing.yaml"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package domain
+
+type ChangeType string
+
+const (
+	CreateChange ChangeType = "create"
+	DeleteChange ChangeType = "delete"
+	// CheckpointChange carries no edge, only the position of the stream to
+	// resume from.
+	CheckpointChange ChangeType = "checkpoint"
+)
+
+// Change is an edge mutation, Token resumes the stream right after it.
+type Change struct {
+	Token string     `json:"token"`
+	Type  ChangeType `json:"type"`
+	Edge  *Edge      `json:"edge,omitempty"`
+}
+
+// ChangesResponse is a page of changes, Token resumes right after it even
+// when it is empty.
+type ChangesResponse struct {
+	Changes []Change `json:"changes"`
+	Token   string   `json:"token"`
+}
@@ -1,36 +1,29 @@
 package domain
 
+import "slices"
+
 type SearchCond struct {
 	In Compare `json:"in"`
 }
 
+// Compare filters edges by the relation and by the vertex the edge leads to.
+// An edge matches when any of the listed attributes holds for it, or with All
+// when every non-empty list holds for it.
 type Compare struct {
 	Nses  []string `json:"nses"`
 	Names []string `json:"names"`
 	Rels  []string `json:"rels"`
+	All   bool     `json:"all"`
 }
 
-func (c *SearchCond) ShouldStop(vertex Vertex) bool {
-	if len(c.In.Nses) == 0 && len(c.In.Names) == 0 && len(c.In.Rels) == 0 {
+// ShouldStop reports whether the traversal should not continue past the edge.
+// isSbj tells whether the edge is walked from subject to object.
+func (c *SearchCond) ShouldStop(edge Edge, isSbj bool) bool {
+	if c.In.isEmpty() {
 		// means no specific condition, never stop
 		return false
 	}
-	for _, ns := range c.In.Nses {
-		if vertex.Ns == ns {
-			return false
-		}
-	}
-	for _, name := range c.In.Names {
-		if vertex.Name == name {
-			return false
-		}
-	}
-	// for _, rel := range c.In.Rels {
-	// 	if vertex.Rel == rel {
-	// 		return false
-	// 	}
-	// }
-	return true
+	return !c.In.match(next(edge, isSbj), edge.Rel)
 }
 
 type CollectCond struct {
@@ -38,25 +31,42 @@ type CollectCond struct {
 	NotIn Compare `json:"not_in"`
 }
 
-func (c *CollectCond) ShouldCollect(vertex Vertex) bool {
-	if len(c.In.Nses) == 0 && len(c.In.Names) == 0 && len(c.In.Rels) == 0 {
-		// means no specific conditions, collect all vertexs
-		return true
+// ShouldCollect reports whether the edge matches In and does not match NotIn.
+func (c *CollectCond) ShouldCollect(edge Edge, isSbj bool) bool {
+	vertex := next(edge, isSbj)
+	if !c.In.isEmpty() && !c.In.match(vertex, edge.Rel) {
+		return false
 	}
-	for _, ns := range c.In.Nses {
-		if vertex.Ns == ns {
-			return true
-		}
+	return c.NotIn.isEmpty() || !c.NotIn.match(vertex, edge.Rel)
+}
+
+func (c *Compare) isEmpty() bool {
+	return len(c.Nses) == 0 && len(c.Names) == 0 && len(c.Rels) == 0
+}
+
+func (c *Compare) match(vertex Vertex, rel string) bool {
+	if c.All {
+		return c.matchAll(vertex, rel)
 	}
-	for _, name := range c.In.Names {
-		if vertex.Name == name {
-			return true
-		}
+	return c.matchAny(vertex, rel)
+}
+
+func (c *Compare) matchAll(vertex Vertex, rel string) bool {
+	return (len(c.Nses) == 0 || slices.Contains(c.Nses, vertex.Ns)) &&
+		(len(c.Names) == 0 || slices.Contains(c.Names, vertex.Name)) &&
+		(len(c.Rels) == 0 || slices.Contains(c.Rels, rel))
+}
+
+func (c *Compare) matchAny(vertex Vertex, rel string) bool {
+	return slices.Contains(c.Nses, vertex.Ns) ||
+		slices.Contains(c.Names, vertex.Name) ||
+		slices.Contains(c.Rels, rel)
+}
+
+// next returns the vertex the edge leads to in the walking direction.
+func next(edge Edge, isSbj bool) Vertex {
+	if isSbj {
+		return Vertex{Ns: edge.VNs, Name: edge.VName}
 	}
-	// for _, rel := range c.In.Rels {
-	// 	if vertex.Rel == rel {
-	// 		return true
-	// 	}
-	// }
-	return false
+	return Vertex{Ns: edge.UNs, Name: edge.UName}
 }
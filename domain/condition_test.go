@@ -0,0 +1,66 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCondition(t *testing.T) {
+	member := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "admin"}
+	read := domain.Edge{UNs: "role", UName: "admin", Rel: "read",
+		VNs: "file", VName: "a"}
+
+	follow := domain.SearchCond{
+		In: domain.Compare{Rels: []string{"member", "parent"}},
+	}
+	assert.False(t, follow.ShouldStop(member, true))
+	assert.True(t, follow.ShouldStop(read, true))
+	assert.False(t, (&domain.SearchCond{}).ShouldStop(read, true))
+
+	toUser := domain.SearchCond{In: domain.Compare{Nses: []string{"user"}}}
+	assert.True(t, toUser.ShouldStop(member, true))
+	assert.False(t, toUser.ShouldStop(member, false))
+
+	toRole := domain.SearchCond{In: domain.Compare{Nses: []string{"role"},
+		Rels: []string{"parent"}}}
+	assert.False(t, toRole.ShouldStop(member, true))
+	toRole.In.All = true
+	assert.True(t, toRole.ShouldStop(member, true))
+
+	tests := []struct {
+		name string
+		cond domain.CollectCond
+		edge domain.Edge
+		want bool
+	}{
+		{"empty", domain.CollectCond{}, member, true},
+		{"in rel", domain.CollectCond{
+			In: domain.Compare{Rels: []string{"read", "write"}}}, read, true},
+		{"not in rel", domain.CollectCond{
+			In: domain.Compare{Rels: []string{"read", "write"}}}, member, false},
+		{"in any attribute", domain.CollectCond{
+			In: domain.Compare{Nses: []string{"file"},
+				Rels: []string{"write"}}}, read, true},
+		{"in all attributes", domain.CollectCond{
+			In: domain.Compare{Nses: []string{"file"},
+				Rels: []string{"write"}, All: true}}, read, false},
+		{"not in ns", domain.CollectCond{
+			NotIn: domain.Compare{Nses: []string{"role", "user"}}}, member, false},
+		{"not in any attribute", domain.CollectCond{
+			NotIn: domain.Compare{Names: []string{"b"},
+				Rels: []string{"read"}}}, read, false},
+		{"not in all attributes", domain.CollectCond{
+			NotIn: domain.Compare{Names: []string{"b"},
+				Rels: []string{"read"}, All: true}}, read, true},
+		{"not in miss", domain.CollectCond{
+			NotIn: domain.Compare{Nses: []string{"role", "user"}}}, read, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cond.ShouldCollect(tt.edge, true))
+		})
+	}
+}
@@ -1,11 +1,71 @@
 package domain
 
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Identifier is what names and relations may consist of, it leaves out the
+// deny prefix and the separators of the "ns:name" notation. The slash is kept
+// for the paths of the rbac namespace, e.g. role/admin.
+var Identifier = regexp.MustCompile(`^[A-Za-z0-9_.@+\-/]+$`)
+
+// NamespaceIdentifier is what namespaces may consist of, an Identifier
+// without the slash so that the paths holding a namespace and a name, e.g.
+// rbac:object/doc/a/b, split at the first slash.
+var NamespaceIdentifier = regexp.MustCompile(`^[A-Za-z0-9_.@+\-]+$`)
+
+const (
+	IdentifierMsg          = "only letters, digits and _.@+-/ are allowed"
+	NamespaceIdentifierMsg = "only letters, digits and _.@+- are allowed"
+	MaxIdentifier          = 256
+)
+
+// Edge is identified by its tenant, subject, relation and object, the Period
+// is not part of its identity. The repositories set the Tenant from the
+// context, see WithTenant.
 type Edge struct {
-	UNs   string `json:"u_ns" bson:"u_ns"`
-	UName string `json:"u_name" bson:"u_name"`
-	Rel   string `json:"rel" bson:"rel"`
-	VNs   string `json:"v_ns" bson:"v_ns"`
-	VName string `json:"v_name" bson:"v_name"`
+	Tenant string `json:"tenant,omitempty" bson:"tenant"`
+	UNs    string `json:"u_ns" bson:"u_ns"`
+	UName  string `json:"u_name" bson:"u_name"`
+	Rel    string `json:"rel" bson:"rel"`
+	VNs    string `json:"v_ns" bson:"v_ns"`
+	VName  string `json:"v_name" bson:"v_name"`
+	Period `bson:",inline"`
+}
+
+// Period bounds the time an edge is in effect, a nil bound is open.
+type Period struct {
+	NotBefore *time.Time `json:"not_before,omitempty" bson:"not_before,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+}
+
+// Active reports whether t falls in [NotBefore, ExpiresAt).
+func (p Period) Active(t time.Time) bool {
+	return (p.NotBefore == nil || !t.Before(*p.NotBefore)) &&
+		(p.ExpiresAt == nil || t.Before(*p.ExpiresAt))
+}
+
+// Valid reports whether the period is not empty.
+func (p Period) Valid() bool {
+	return p.NotBefore == nil || p.ExpiresAt == nil ||
+		p.NotBefore.Before(*p.ExpiresAt)
+}
+
+// DenyPrefix marks the relation of a deny edge, "!read" revokes the read
+// granted by any other path. Denies override grants.
+const DenyPrefix = "!"
+
+// DenyRel returns the relation of the deny edge which revokes rel.
+func DenyRel(rel string) string {
+	return DenyPrefix + rel
+}
+
+// DeniedRel returns the relation revoked by rel, ok is false if rel is not a
+// deny relation.
+func DeniedRel(rel string) (denied string, ok bool) {
+	return strings.CutPrefix(rel, DenyPrefix)
 }
 
 type Vertex struct {
@@ -25,6 +85,61 @@ type Permission struct {
 	Name string
 }
 
+type CheckRequest struct {
+	Sbj Vertex `json:"sbj"`
+	Rel string `json:"rel"`
+	Obj Vertex `json:"obj"`
+}
+
+type CheckResult struct {
+	CheckRequest
+	Ok bool `json:"ok"`
+}
+
+// Explanation holds the path that grants a permission, or the closest
+// partial paths when it is denied.
+type Explanation struct {
+	Granted bool     `json:"granted"`
+	Paths   [][]Edge `json:"paths"`
+}
+
+// CacheStats counts the answers of the traversal cache since the start.
+type CacheStats struct {
+	Enabled bool   `json:"enabled"`
+	Entries int    `json:"entries"`
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+}
+
+// ClosureReport compares the role closure of a tenant with the one rebuilt
+// from its edges.
+type ClosureReport struct {
+	Enabled    bool `json:"enabled"`
+	Consistent bool `json:"consistent"`
+	// Entries counts the roles held by the users and roles of the closure.
+	Entries int `json:"entries"`
+	// Missing are held according to the edges only, Extra according to the
+	// closure only.
+	Missing []ClosureEntry `json:"missing"`
+	Extra   []ClosureEntry `json:"extra"`
+}
+
+// ClosureEntry is a role held by a user or a role, through edges without a
+// period or, when Timed is set, directly by an edge with one.
+type ClosureEntry struct {
+	Sbj   Vertex `json:"sbj"`
+	Role  string `json:"role"`
+	Timed bool   `json:"timed,omitempty"`
+}
+
 type Response struct {
 	Msg string `json:"msg"`
 }
+
+// ErrorResponse is the body of every failed REST request, Code is stable
+// across releases while Message is meant for humans.
+type ErrorResponse struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
@@ -8,4 +8,6 @@ var (
 	ErrNotImplemented  = errors.New("not implemented")
 	ErrDuplicateRecord = errors.New("duplicate record")
 	ErrBodyAttribute   = errors.New("body attribute error")
+	ErrUnavailable     = errors.New("service unavailable")
+	ErrTokenExpired    = errors.New("resume token expired")
 )
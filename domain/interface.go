@@ -2,19 +2,42 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
+// DbRepository only ever sees the edges of the tenant of the context, but for
+// DeleteExpired and ClearAll which span every tenant.
 type DbRepository interface {
 	Ping(c context.Context) error
 	Get(c context.Context, edge Edge, queryMode bool) (edges []Edge, err error)
+	// GetNeighbors returns in one round-trip the edges leaving the vertices
+	// when isSbj is set, or else the edges reaching them.
+	GetNeighbors(c context.Context, vertices []Vertex, isSbj bool) (
+		edges []Edge, err error)
+	// Create returns ErrDuplicateRecord if the edge is already stored.
 	Create(c context.Context, edge Edge) error
 	Delete(c context.Context, edge Edge, queryMode bool) error
+	// ApplyOperations applies all the operations or none of them.
+	ApplyOperations(c context.Context, operations []Operation) error
+	// DeleteExpired removes the edges which expired at or before now.
+	DeleteExpired(c context.Context, now time.Time) (deleted int64, err error)
 	ClearAll(c context.Context) error
+	// Watch sends a checkpoint, then every change after token, or after now
+	// when token is empty, in order. It returns the error of send, c.Err()
+	// once c is done, or ErrTokenExpired when the changes after token are
+	// not retained anymore.
+	Watch(c context.Context, token string, send func(Change) error) error
 }
 
 type GraphInfra interface {
 	Check(c context.Context, start Vertex, target Vertex, relation string,
 		searchCond SearchCond) (found bool, err error)
+	Explain(c context.Context, start Vertex, target Vertex, relation string,
+		searchCond SearchCond, maxDepth int) (explanation *Explanation, err error)
+	// BulkCheck answers every check, walking the graph once per distinct
+	// subject.
+	BulkCheck(c context.Context, checks []CheckRequest, searchCond SearchCond) (
+		results []bool, err error)
 	SearchPermissions(c context.Context, start Vertex, isSbj bool,
 		searchCond SearchCond, collectCond CollectCond, maxDepth int) (
 		permissions []Permission, err error)
@@ -22,6 +45,18 @@ type GraphInfra interface {
 		searchCond SearchCond, collectCond CollectCond, maxDepth int) (
 		permissions []Vertex, err error)
 	GetTree(c context.Context, sbj Vertex, maxDepth int) (*TreeNode, error)
+	FindCycles(c context.Context) (cycles [][]Vertex, err error)
+}
+
+// SchemaInfra holds a schema per tenant, the one of the tenant of c.
+type SchemaInfra interface {
+	GetSchema(c context.Context) Schema
+	// SetSchema replaces the whole schema, an invalid one is rejected with
+	// ErrBodyAttribute.
+	SetSchema(c context.Context, schema Schema) error
+	Namespace(c context.Context, ns string) (config NamespaceConfig, ok bool)
+	Relation(c context.Context, ns string, rel string) (config RelationConfig,
+		ok bool)
 }
 
 type Usecase interface {
@@ -31,18 +66,31 @@ type Usecase interface {
 	UserGetRoles(c context.Context, name string) ([]string, error)
 	UserCheck(c context.Context, username string, objNs string, relation string,
 		objName string) (bool, error)
-	UserAddPermission(c context.Context, username string, permission Permission) error
+	UserExplain(c context.Context, username string, objNs string, relation string,
+		objName string, maxDepth int) (*Explanation, error)
+	BulkCheck(c context.Context, checks []CheckRequest) ([]CheckResult, error)
+	UserAddPermission(c context.Context, username string, permission Permission,
+		period Period, ifNotExists bool) error
 	UserRemovePermission(c context.Context, username string,
 		permission Permission) error
-	UserAddRole(c context.Context, username string, roleName string) error
+	UserAddRole(c context.Context, username string, roleName string,
+		period Period, ifNotExists bool) error
 	UserRemoveRole(c context.Context, username string, roleName string) error
 	DeleteRole(c context.Context, name string) error
 	RoleGetUsers(c context.Context, name string) ([]string, error)
 	RoleGetPermissions(c context.Context, name string) ([]Permission, error)
-	RoleAddPermission(c context.Context, roleName string, permission Permission) error
+	RoleAddPermission(c context.Context, roleName string, permission Permission,
+		period Period, ifNotExists bool) error
 	RoleRemovePermission(c context.Context, roleName string,
 		permission Permission) error
-	RoleInheritRole(c context.Context, parentName string, childName string) error
+	UserAddDeny(c context.Context, username string, permission Permission,
+		period Period, ifNotExists bool) error
+	UserRemoveDeny(c context.Context, username string, permission Permission) error
+	RoleAddDeny(c context.Context, roleName string, permission Permission,
+		period Period, ifNotExists bool) error
+	RoleRemoveDeny(c context.Context, roleName string, permission Permission) error
+	RoleInheritRole(c context.Context, parentName string, childName string,
+		ifNotExists bool) error
 	RoleUnInheritRole(c context.Context, parentName string, childName string) error
 	RoleGetChildRole(c context.Context, name string) ([]string, error)
 	RoleGetParentRole(c context.Context, name string) ([]string, error)
@@ -51,4 +99,17 @@ type Usecase interface {
 		[]string, error)
 	WhichUserHasPermission(c context.Context, objNs string, objName string) (
 		[]string, error)
+	FindCycles(c context.Context) ([][]Vertex, error)
+	ApplyOperations(c context.Context, operations []Operation) error
+	GetSchema(c context.Context) (Schema, error)
+	SetSchema(c context.Context, schema Schema) error
+	// ExportTenant returns every edge of the tenant of the context.
+	ExportTenant(c context.Context) ([]Edge, error)
+	// PurgeTenant deletes every edge of the tenant of the context.
+	PurgeTenant(c context.Context) error
+	Watch(c context.Context, token string, send func(Change) error) error
+	CacheStats(c context.Context) CacheStats
+	// RebuildClosure rebuilds the role closure of the tenant from its edges.
+	RebuildClosure(c context.Context) error
+	VerifyClosure(c context.Context) (ClosureReport, error)
 }
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+type revisionKey struct{}
+
+// Revision is the consistency of a request. Its reads must observe the edges
+// at least as fresh as AtLeast, and its reads and writes report the revision
+// they reached so that the client can pass it on to its next request. The
+// tokens are opaque and only meaningful to the repository issuing them, a
+// cache must not answer from a state older than AtLeast.
+type Revision struct {
+	AtLeast string
+
+	mu      sync.Mutex
+	reached string
+	// shared with the derived revisions, nil outside of a request
+	scope *scope
+}
+
+// Session is what a repository keeps across the operations of a request,
+// e.g. its causally consistent database session.
+type Session interface {
+	End()
+}
+
+type scope struct {
+	mu      sync.Mutex
+	session Session
+}
+
+// NewRevision returns the revision of a request reading at least atLeast,
+// End releases the session its operations kept once the request is done.
+func NewRevision(atLeast string) *Revision {
+	return &Revision{AtLeast: atLeast, scope: &scope{}}
+}
+
+// Derive returns a revision of the same request, reading at least as fresh
+// as r and sharing its session, which records the revision it reaches apart
+// from r.
+func (r *Revision) Derive() *Revision {
+	return &Revision{AtLeast: r.AtLeast, scope: r.scope}
+}
+
+// Session returns the session of the request, started by start on first use
+// and kept until End. Outside of a request every call starts its own
+// session, which release ends.
+func (r *Revision) Session(start func() (Session, error)) (
+	session Session, release func(), err error) {
+	if r.scope == nil {
+		session, err = start()
+		if err != nil {
+			return nil, nil, err
+		}
+		return session, session.End, nil
+	}
+	r.scope.mu.Lock()
+	defer r.scope.mu.Unlock()
+	if r.scope.session == nil {
+		if r.scope.session, err = start(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return r.scope.session, func() {}, nil
+}
+
+// End ends the session of the request, if any.
+func (r *Revision) End() {
+	if r.scope == nil {
+		return
+	}
+	r.scope.mu.Lock()
+	defer r.scope.mu.Unlock()
+	if r.scope.session != nil {
+		r.scope.session.End()
+		r.scope.session = nil
+	}
+}
+
+// Reach records the revision reached by an operation, the operations of a
+// request run one after the other so the last one is the freshest.
+func (r *Revision) Reach(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reached = token
+}
+
+func (r *Revision) Reached() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reached
+}
+
+func WithRevision(c context.Context, r *Revision) context.Context {
+	return context.WithValue(c, revisionKey{}, r)
+}
+
+// RevisionFrom returns the revision of the context, a fresh one which nobody
+// reads when there is none.
+func RevisionFrom(c context.Context) *Revision {
+	if r, ok := c.Value(revisionKey{}).(*Revision); ok {
+		return r
+	}
+	return &Revision{}
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type session struct{ ended *int }
+
+func (s session) End() { *s.ended++ }
+
+func TestRevisionSession(t *testing.T) {
+	started, ended := 0, 0
+	start := func() (domain.Session, error) {
+		started++
+		return session{ended: &ended}, nil
+	}
+
+	// the operations of a request, derived revisions included, share one
+	// session until the request ends
+	rev := domain.NewRevision("")
+	for _, r := range []*domain.Revision{rev, rev, rev.Derive()} {
+		_, release, err := r.Session(start)
+		assert.NoError(t, err)
+		release()
+	}
+	assert.Equal(t, 1, started)
+	assert.Equal(t, 0, ended)
+	rev.End()
+	assert.Equal(t, 1, ended)
+
+	// outside of a request each operation has its own
+	_, release, err := domain.RevisionFrom(context.Background()).Session(start)
+	assert.NoError(t, err)
+	release()
+	assert.Equal(t, 2, started)
+	assert.Equal(t, 2, ended)
+}
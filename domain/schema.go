@@ -0,0 +1,38 @@
+package domain
+
+// Schema declares the namespaces and the rewrite rules of their relations.
+// Namespaces missing from the schema keep free-form relations unless Strict is
+// set. The reserved role and user namespaces are built in and not declared.
+type Schema struct {
+	Strict     bool              `json:"strict" yaml:"strict"`
+	Namespaces []NamespaceConfig `json:"namespaces" yaml:"namespaces"`
+}
+
+type NamespaceConfig struct {
+	Name      string           `json:"name" yaml:"name"`
+	Relations []RelationConfig `json:"relations" yaml:"relations"`
+}
+
+// RelationConfig grants the relation to the subjects of its own edges and to
+// the subjects reached by the rewrite rules, as in Zanzibar.
+type RelationConfig struct {
+	Name string `json:"name" yaml:"name"`
+	// Subjects are the namespaces allowed to hold the relation, empty means
+	// any namespace.
+	Subjects []string `json:"subjects" yaml:"subjects"`
+	// ComputedUsersets are relations on the same object which imply this
+	// one, e.g. viewer lists editor.
+	ComputedUsersets []string `json:"computed_usersets" yaml:"computed_usersets"`
+	// TupleToUsersets take the relation from related objects, e.g. the
+	// viewers of a folder are viewers of the docs in it.
+	TupleToUsersets []TupleToUserset `json:"tuple_to_usersets" yaml:"tuple_to_usersets"`
+}
+
+// TupleToUserset follows the edges "x -Tupleset-> object" and grants the
+// relation to whoever holds ComputedUserset on x. With Tupleset "folder" and
+// ComputedUserset "viewer", the edge "folder:a -folder-> doc:b" makes the
+// viewers of folder:a viewers of doc:b, "parent" being reserved.
+type TupleToUserset struct {
+	Tupleset        string `json:"tupleset" yaml:"tupleset"`
+	ComputedUserset string `json:"computed_userset" yaml:"computed_userset"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant scopes the context to the tenant, the repositories only read and
+// write the edges of the tenant of their context. The default tenant is "".
+func WithTenant(c context.Context, tenant string) context.Context {
+	return context.WithValue(c, tenantKey{}, tenant)
+}
+
+func TenantFrom(c context.Context) string {
+	tenant, _ := c.Value(tenantKey{}).(string)
+	return tenant
+}
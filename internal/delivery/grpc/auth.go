@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"path"
+
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/proto"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// scopes is the scope each rpc requires, like its route in the REST API. The
+// rpcs missing from it require the admin scope, except the probe.
+var scopes = map[string]middleware.Scope{
+	"UserGetPermissions":     middleware.ReadScope,
+	"UserGetRoles":           middleware.ReadScope,
+	"UserCheck":              middleware.ReadScope,
+	"UserExplain":            middleware.ReadScope,
+	"BulkCheck":              middleware.ReadScope,
+	"RoleGetUsers":           middleware.ReadScope,
+	"RoleGetPermissions":     middleware.ReadScope,
+	"RoleGetChildRole":       middleware.ReadScope,
+	"RoleGetParentRole":      middleware.ReadScope,
+	"WhichRoleHasPermission": middleware.ReadScope,
+	"WhichUserHasPermission": middleware.ReadScope,
+	"GetSchema":              middleware.ReadScope,
+	"Watch":                  middleware.ReadScope,
+	"UserAddPermission":      middleware.WriteScope,
+	"UserRemovePermission":   middleware.WriteScope,
+	"UserAddDeny":            middleware.WriteScope,
+	"UserRemoveDeny":         middleware.WriteScope,
+	"UserAddRole":            middleware.WriteScope,
+	"UserRemoveRole":         middleware.WriteScope,
+	"RoleAddPermission":      middleware.WriteScope,
+	"RoleRemovePermission":   middleware.WriteScope,
+	"RoleAddDeny":            middleware.WriteScope,
+	"RoleRemoveDeny":         middleware.WriteScope,
+	"RoleInheritRole":        middleware.WriteScope,
+	"RoleUnInheritRole":      middleware.WriteScope,
+	"DeleteObject":           middleware.WriteScope,
+	"ApplyOperations":        middleware.WriteScope,
+}
+
+type principalKey struct{}
+
+// AuthInterceptor identifies the caller of every rpc with the authenticators
+// of the REST API, reading the headers they expect from the metadata, e.g.
+// x-api-key or authorization, and requires the scope of the rpc. Without
+// authenticators every caller is an anonymous admin.
+func AuthInterceptor(
+	authenticators ...middleware.Authenticator) grpc.UnaryServerInterceptor {
+	return func(c context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+		c, err := authenticate(c, info.FullMethod, authenticators)
+		if err != nil {
+			return nil, err
+		}
+		return handler(c, req)
+	}
+}
+
+// AuthStreamInterceptor is the AuthInterceptor of the streaming rpcs.
+func AuthStreamInterceptor(
+	authenticators ...middleware.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		c, err := authenticate(stream.Context(), info.FullMethod,
+			authenticators)
+		if err != nil {
+			return err
+		}
+		return handler(srv, contextStream{ServerStream: stream, c: c})
+	}
+}
+
+func authenticate(c context.Context, method string,
+	authenticators []middleware.Authenticator) (context.Context, error) {
+	if method == proto.RbacService_Healthy_FullMethodName {
+		return c, nil
+	}
+	principal := middleware.Principal{
+		Scopes: []middleware.Scope{middleware.AdminScope},
+	}
+	if len(authenticators) > 0 {
+		md, _ := metadata.FromIncomingContext(c)
+		r := &http.Request{Header: http.Header{}}
+		for key, values := range md {
+			r.Header[http.CanonicalHeaderKey(key)] = values
+		}
+		var err error
+		if principal, err = middleware.Identify(r,
+			authenticators...); err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+	}
+
+	scope, ok := scopes[path.Base(method)]
+	if !ok {
+		scope = middleware.AdminScope
+	}
+	if !principal.Has(scope) {
+		return nil, status.Error(codes.PermissionDenied,
+			"scope "+string(scope)+" is required")
+	}
+	return context.WithValue(c, principalKey{}, principal), nil
+}
+
+// principalFrom returns the caller identified by AuthInterceptor.
+func principalFrom(c context.Context) (middleware.Principal, bool) {
+	principal, ok := c.Value(principalKey{}).(middleware.Principal)
+	return principal, ok
+}
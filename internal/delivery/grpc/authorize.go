@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"path"
+
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/proto"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthzInterceptor requires manage on the rbac objects of every change, the
+// same as the routes of the REST API, from the caller identified by
+// AuthInterceptor. It must run after TenantInterceptor, the checks are made
+// in the tenant of the rpc. A nil authz authorizes everything.
+func AuthzInterceptor(authz *middleware.Authorizer) grpc.UnaryServerInterceptor {
+	return func(c context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+		objects := managed(info.FullMethod, req)
+		if authz == nil || len(objects) == 0 {
+			return handler(c, req)
+		}
+		principal, ok := principalFrom(c)
+		if !ok || principal.Subject == "" {
+			return nil, status.Error(codes.Unauthenticated,
+				middleware.ErrNoCredentials.Error())
+		}
+		denied, err := authz.Authorize(c, principal, objects...)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if denied != "" {
+			return nil, status.Error(codes.PermissionDenied,
+				principal.Subject+" has no "+middleware.AuthzRel+" on "+
+					middleware.AuthzNs+":"+denied)
+		}
+		return handler(c, req)
+	}
+}
+
+// managed returns the rbac objects the rpc changes, none for the queries.
+// The changes it does not know require rbac:system.
+func managed(method string, req any) []string {
+	switch name := path.Base(method); name {
+	case "DeleteUser":
+		return []string{"user/" + req.(*proto.NameRequest).GetName()}
+	case "UserAddPermission", "UserRemovePermission", "UserAddDeny",
+		"UserRemoveDeny":
+		r := req.(*proto.UserPermissionRequest)
+		return []string{"user/" + r.GetUsername(), target(r.GetPermission())}
+	// the members of a role are managed by the managers of the role
+	case "UserAddRole", "UserRemoveRole":
+		return []string{"role/" + req.(*proto.UserRoleRequest).GetRoleName()}
+	case "DeleteRole":
+		return []string{"role/" + req.(*proto.NameRequest).GetName()}
+	case "RoleAddPermission", "RoleRemovePermission", "RoleAddDeny",
+		"RoleRemoveDeny":
+		r := req.(*proto.RolePermissionRequest)
+		return []string{"role/" + r.GetRoleName(), target(r.GetPermission())}
+	case "RoleInheritRole", "RoleUnInheritRole":
+		r := req.(*proto.RoleInheritRequest)
+		return []string{"role/" + r.GetParentName(), "role/" + r.GetChildName()}
+	case "DeleteObject":
+		r := req.(*proto.ObjectRequest)
+		return []string{middleware.TargetObject(r.GetNs(), r.GetName())}
+	default:
+		if method == proto.RbacService_Healthy_FullMethodName ||
+			scopes[name] == middleware.ReadScope {
+			return nil
+		}
+		return []string{middleware.SystemObject}
+	}
+}
+
+func target(p *proto.Permission) string {
+	return middleware.TargetObject(p.GetNs(), p.GetName())
+}
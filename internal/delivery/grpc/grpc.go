@@ -0,0 +1,606 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"time"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/proto"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	tenantKey   = "x-tenant"
+	revisionKey = "x-revision"
+)
+
+type GrpcDelivery struct {
+	proto.UnimplementedRbacServiceServer
+	usecase domain.Usecase
+}
+
+func NewDelivery(usecase domain.Usecase) *GrpcDelivery {
+	return &GrpcDelivery{usecase: usecase}
+}
+
+func (d *GrpcDelivery) Healthy(c context.Context, _ *emptypb.Empty) (
+	*emptypb.Empty, error) {
+	if err := d.usecase.Healthy(c); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) DeleteUser(c context.Context, req *proto.NameRequest) (
+	*emptypb.Empty, error) {
+	if err := d.usecase.DeleteUser(c, req.Name); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) UserGetPermissions(c context.Context,
+	req *proto.NameRequest) (*proto.PermissionsResponse, error) {
+	pers, err := d.usecase.UserGetPermissions(c, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.PermissionsResponse{Permissions: toPermissions(pers)}, nil
+}
+
+func (d *GrpcDelivery) UserGetRoles(c context.Context, req *proto.NameRequest) (
+	*proto.NamesResponse, error) {
+	roles, err := d.usecase.UserGetRoles(c, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.NamesResponse{Names: roles}, nil
+}
+
+func (d *GrpcDelivery) UserCheck(c context.Context, req *proto.UserCheckRequest) (
+	*proto.UserCheckResponse, error) {
+	ok, err := d.usecase.UserCheck(c, req.Username, req.ObjNs, req.Relation,
+		req.ObjName)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.UserCheckResponse{Ok: ok}, nil
+}
+
+func (d *GrpcDelivery) UserExplain(c context.Context,
+	req *proto.UserExplainRequest) (*proto.Explanation, error) {
+	maxDepth := math.MaxInt
+	if req.MaxDepth > 0 {
+		maxDepth = int(req.MaxDepth)
+	}
+	explanation, err := d.usecase.UserExplain(c, req.Username, req.ObjNs,
+		req.Relation, req.ObjName, maxDepth)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &proto.Explanation{
+		Granted: explanation.Granted,
+		Paths:   make([]*proto.Path, len(explanation.Paths)),
+	}
+	for i, path := range explanation.Paths {
+		edges := make([]*proto.Edge, len(path))
+		for j, edge := range path {
+			edges[j] = toEdge(edge)
+		}
+		res.Paths[i] = &proto.Path{Edges: edges}
+	}
+	return res, nil
+}
+
+func (d *GrpcDelivery) BulkCheck(c context.Context, req *proto.BulkCheckRequest) (
+	*proto.BulkCheckResponse, error) {
+	checks := make([]domain.CheckRequest, len(req.Checks))
+	for i, check := range req.Checks {
+		checks[i] = domain.CheckRequest{
+			Sbj: fromVertex(check.GetSbj()),
+			Rel: check.GetRel(),
+			Obj: fromVertex(check.GetObj()),
+		}
+	}
+	results, err := d.usecase.BulkCheck(c, checks)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &proto.BulkCheckResponse{
+		Results: make([]*proto.CheckResult, len(results)),
+	}
+	for i, result := range results {
+		res.Results[i] = &proto.CheckResult{Check: req.Checks[i], Ok: result.Ok}
+	}
+	return res, nil
+}
+
+func (d *GrpcDelivery) UserAddPermission(c context.Context,
+	req *proto.UserPermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.UserAddPermission(c, req.Username,
+		fromPermission(req.Permission), fromPeriod(req.Period),
+		req.IfNotExists); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) UserRemovePermission(c context.Context,
+	req *proto.UserPermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.UserRemovePermission(c, req.Username,
+		fromPermission(req.Permission)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) UserAddDeny(c context.Context,
+	req *proto.UserPermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.UserAddDeny(c, req.Username,
+		fromPermission(req.Permission), fromPeriod(req.Period),
+		req.IfNotExists); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) UserRemoveDeny(c context.Context,
+	req *proto.UserPermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.UserRemoveDeny(c, req.Username,
+		fromPermission(req.Permission)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) UserAddRole(c context.Context, req *proto.UserRoleRequest) (
+	*emptypb.Empty, error) {
+	if err := d.usecase.UserAddRole(c, req.Username, req.RoleName,
+		fromPeriod(req.Period), req.IfNotExists); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) UserRemoveRole(c context.Context,
+	req *proto.UserRoleRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.UserRemoveRole(c, req.Username, req.RoleName); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) DeleteRole(c context.Context, req *proto.NameRequest) (
+	*emptypb.Empty, error) {
+	if err := d.usecase.DeleteRole(c, req.Name); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) RoleGetUsers(c context.Context, req *proto.NameRequest) (
+	*proto.NamesResponse, error) {
+	users, err := d.usecase.RoleGetUsers(c, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.NamesResponse{Names: users}, nil
+}
+
+func (d *GrpcDelivery) RoleGetPermissions(c context.Context,
+	req *proto.NameRequest) (*proto.PermissionsResponse, error) {
+	pers, err := d.usecase.RoleGetPermissions(c, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.PermissionsResponse{Permissions: toPermissions(pers)}, nil
+}
+
+func (d *GrpcDelivery) RoleAddPermission(c context.Context,
+	req *proto.RolePermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.RoleAddPermission(c, req.RoleName,
+		fromPermission(req.Permission), fromPeriod(req.Period),
+		req.IfNotExists); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) RoleRemovePermission(c context.Context,
+	req *proto.RolePermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.RoleRemovePermission(c, req.RoleName,
+		fromPermission(req.Permission)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) RoleAddDeny(c context.Context,
+	req *proto.RolePermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.RoleAddDeny(c, req.RoleName,
+		fromPermission(req.Permission), fromPeriod(req.Period),
+		req.IfNotExists); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) RoleRemoveDeny(c context.Context,
+	req *proto.RolePermissionRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.RoleRemoveDeny(c, req.RoleName,
+		fromPermission(req.Permission)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) RoleInheritRole(c context.Context,
+	req *proto.RoleInheritRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.RoleInheritRole(c, req.ParentName,
+		req.ChildName, req.IfNotExists); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) RoleUnInheritRole(c context.Context,
+	req *proto.RoleInheritRequest) (*emptypb.Empty, error) {
+	if err := d.usecase.RoleUnInheritRole(c, req.ParentName,
+		req.ChildName); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) RoleGetChildRole(c context.Context,
+	req *proto.NameRequest) (*proto.NamesResponse, error) {
+	roles, err := d.usecase.RoleGetChildRole(c, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.NamesResponse{Names: roles}, nil
+}
+
+func (d *GrpcDelivery) RoleGetParentRole(c context.Context,
+	req *proto.NameRequest) (*proto.NamesResponse, error) {
+	roles, err := d.usecase.RoleGetParentRole(c, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.NamesResponse{Names: roles}, nil
+}
+
+func (d *GrpcDelivery) DeleteObject(c context.Context, req *proto.ObjectRequest) (
+	*emptypb.Empty, error) {
+	if err := d.usecase.DeleteObject(c, req.Ns, req.Name); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) WhichRoleHasPermission(c context.Context,
+	req *proto.ObjectRequest) (*proto.NamesResponse, error) {
+	roles, err := d.usecase.WhichRoleHasPermission(c, req.Ns, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.NamesResponse{Names: roles}, nil
+}
+
+func (d *GrpcDelivery) WhichUserHasPermission(c context.Context,
+	req *proto.ObjectRequest) (*proto.NamesResponse, error) {
+	users, err := d.usecase.WhichUserHasPermission(c, req.Ns, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.NamesResponse{Names: users}, nil
+}
+
+func (d *GrpcDelivery) FindCycles(c context.Context, _ *emptypb.Empty) (
+	*proto.FindCyclesResponse, error) {
+	cycles, err := d.usecase.FindCycles(c)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &proto.FindCyclesResponse{
+		Cycles: make([]*proto.Cycle, len(cycles)),
+	}
+	for i, cycle := range cycles {
+		vertices := make([]*proto.Vertex, len(cycle))
+		for j, v := range cycle {
+			vertices[j] = &proto.Vertex{Ns: v.Ns, Name: v.Name}
+		}
+		res.Cycles[i] = &proto.Cycle{Vertices: vertices}
+	}
+	return res, nil
+}
+
+func (d *GrpcDelivery) ApplyOperations(c context.Context,
+	req *proto.ApplyOperationsRequest) (*emptypb.Empty, error) {
+	operations := make([]domain.Operation, len(req.Operations))
+	for i, op := range req.Operations {
+		operations[i] = domain.Operation{
+			Type: domain.Action(op.Action),
+			Edge: fromEdge(op.Edge),
+		}
+	}
+	if err := d.usecase.ApplyOperations(c, operations); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) GetSchema(c context.Context, _ *emptypb.Empty) (
+	*proto.Schema, error) {
+	schema, err := d.usecase.GetSchema(c)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &proto.Schema{
+		Namespaces: make([]*proto.NamespaceConfig, len(schema.Namespaces)),
+		Strict:     schema.Strict,
+	}
+	for i, ns := range schema.Namespaces {
+		relations := make([]*proto.RelationConfig, len(ns.Relations))
+		for j, rel := range ns.Relations {
+			ttus := make([]*proto.TupleToUserset, len(rel.TupleToUsersets))
+			for k, ttu := range rel.TupleToUsersets {
+				ttus[k] = &proto.TupleToUserset{
+					Tupleset:        ttu.Tupleset,
+					ComputedUserset: ttu.ComputedUserset,
+				}
+			}
+			relations[j] = &proto.RelationConfig{
+				Name:             rel.Name,
+				Subjects:         rel.Subjects,
+				ComputedUsersets: rel.ComputedUsersets,
+				TupleToUsersets:  ttus,
+			}
+		}
+		res.Namespaces[i] = &proto.NamespaceConfig{
+			Name:      ns.Name,
+			Relations: relations,
+		}
+	}
+	return res, nil
+}
+
+func (d *GrpcDelivery) SetSchema(c context.Context, req *proto.Schema) (
+	*emptypb.Empty, error) {
+	schema := domain.Schema{
+		Namespaces: make([]domain.NamespaceConfig, len(req.Namespaces)),
+		Strict:     req.Strict,
+	}
+	for i, ns := range req.Namespaces {
+		relations := make([]domain.RelationConfig, len(ns.Relations))
+		for j, rel := range ns.Relations {
+			ttus := make([]domain.TupleToUserset, len(rel.TupleToUsersets))
+			for k, ttu := range rel.TupleToUsersets {
+				ttus[k] = domain.TupleToUserset{
+					Tupleset:        ttu.Tupleset,
+					ComputedUserset: ttu.ComputedUserset,
+				}
+			}
+			relations[j] = domain.RelationConfig{
+				Name:             rel.Name,
+				Subjects:         rel.Subjects,
+				ComputedUsersets: rel.ComputedUsersets,
+				TupleToUsersets:  ttus,
+			}
+		}
+		schema.Namespaces[i] = domain.NamespaceConfig{
+			Name:      ns.Name,
+			Relations: relations,
+		}
+	}
+	if err := d.usecase.SetSchema(c, schema); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (d *GrpcDelivery) ExportTenant(c context.Context, _ *emptypb.Empty) (
+	*proto.EdgesResponse, error) {
+	edges, err := d.usecase.ExportTenant(c)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &proto.EdgesResponse{Edges: make([]*proto.Edge, len(edges))}
+	for i, e := range edges {
+		res.Edges[i] = toEdge(e)
+	}
+	return res, nil
+}
+
+func (d *GrpcDelivery) PurgeTenant(c context.Context, _ *emptypb.Empty) (
+	*emptypb.Empty, error) {
+	if err := d.usecase.PurgeTenant(c); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Watch streams the changes after the token of the request, starting with a
+// checkpoint.
+func (d *GrpcDelivery) Watch(req *proto.WatchRequest,
+	stream proto.RbacService_WatchServer) error {
+	err := d.usecase.Watch(stream.Context(), req.GetToken(),
+		func(change domain.Change) error {
+			res := &proto.Change{Token: change.Token, Type: string(change.Type)}
+			if change.Edge != nil {
+				res.Edge = toEdge(*change.Edge)
+			}
+			return stream.Send(res)
+		})
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	return toStatus(err)
+}
+
+// TenantInterceptor scopes the context of every rpc to the tenant of the
+// x-tenant metadata, validated and bound to the caller identified by
+// AuthInterceptor like the tenant of the REST API.
+func TenantInterceptor(c context.Context, req any, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	c, err := withTenant(c)
+	if err != nil {
+		return nil, err
+	}
+	return handler(c, req)
+}
+
+// RevisionInterceptor makes the reads of an rpc at least as fresh as the
+// revision of the x-revision metadata and answers the revision the rpc
+// reached in the x-revision header.
+func RevisionInterceptor(c context.Context, req any,
+	_ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var atLeast string
+	if revisions := metadata.ValueFromIncomingContext(c, revisionKey); len(
+		revisions) > 0 {
+		atLeast = revisions[0]
+	}
+	rev := domain.NewRevision(atLeast)
+	defer rev.End()
+	res, err := handler(domain.WithRevision(c, rev), req)
+	if reached := rev.Reached(); reached != "" {
+		_ = grpc.SetHeader(c, metadata.Pairs(revisionKey, reached))
+	}
+	return res, err
+}
+
+// TenantStreamInterceptor is the TenantInterceptor of the streaming rpcs.
+func TenantStreamInterceptor(srv any, stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	c, err := withTenant(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, contextStream{ServerStream: stream, c: c})
+}
+
+// contextStream replaces the context of a stream.
+type contextStream struct {
+	grpc.ServerStream
+	c context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.c
+}
+
+func withTenant(c context.Context) (context.Context, error) {
+	tenant := ""
+	if tenants := metadata.ValueFromIncomingContext(c, tenantKey); len(
+		tenants) > 0 {
+		tenant = tenants[0]
+	}
+	principal, _ := principalFrom(c)
+	tenant, err := middleware.BindTenant(tenant, principal)
+	switch {
+	case errors.Is(err, middleware.ErrTenantOutOfReach):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return domain.WithTenant(c, tenant), nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrBodyAttribute):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrDuplicateRecord):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrGraphCycle):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrNotImplemented):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, domain.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, domain.ErrTokenExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toPermissions(pers []domain.Permission) []*proto.Permission {
+	res := make([]*proto.Permission, len(pers))
+	for i, p := range pers {
+		res[i] = &proto.Permission{Rel: p.Rel, Ns: p.Ns, Name: p.Name}
+	}
+	return res
+}
+
+func fromPermission(p *proto.Permission) domain.Permission {
+	return domain.Permission{
+		Rel:  p.GetRel(),
+		Ns:   p.GetNs(),
+		Name: p.GetName(),
+	}
+}
+
+func fromVertex(v *proto.Vertex) domain.Vertex {
+	return domain.Vertex{
+		Ns:   v.GetNs(),
+		Name: v.GetName(),
+	}
+}
+
+func toEdge(e domain.Edge) *proto.Edge {
+	return &proto.Edge{
+		UNs:   e.UNs,
+		UName: e.UName,
+		Rel:   e.Rel,
+		VNs:   e.VNs,
+		VName: e.VName,
+		Period: &proto.Period{
+			NotBefore: toTimestamp(e.NotBefore),
+			ExpiresAt: toTimestamp(e.ExpiresAt),
+		},
+	}
+}
+
+func fromEdge(e *proto.Edge) domain.Edge {
+	return domain.Edge{
+		UNs:    e.GetUNs(),
+		UName:  e.GetUName(),
+		Rel:    e.GetRel(),
+		VNs:    e.GetVNs(),
+		VName:  e.GetVName(),
+		Period: fromPeriod(e.GetPeriod()),
+	}
+}
+
+func fromPeriod(p *proto.Period) domain.Period {
+	return domain.Period{
+		NotBefore: fromTimestamp(p.GetNotBefore()),
+		ExpiresAt: fromTimestamp(p.GetExpiresAt()),
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,267 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	grpcDelivery "github.com/skyrocketOoO/RBAC-server/internal/delivery/grpc"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/proto"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeUsecase accepts every change it implements, checks are answered from
+// grants.
+type fakeUsecase struct {
+	domain.Usecase
+	grants []string
+}
+
+func (f *fakeUsecase) Healthy(c context.Context) error {
+	return nil
+}
+
+func (f *fakeUsecase) SetSchema(c context.Context, schema domain.Schema) error {
+	return nil
+}
+
+func (f *fakeUsecase) UserCheck(c context.Context, username string,
+	objNs string, relation string, objName string) (bool, error) {
+	return slices.Contains(f.grants, username+" "+relation+" "+objNs+":"+
+		objName), nil
+}
+
+// UserGetRoles answers the tenant of the call.
+func (f *fakeUsecase) UserGetRoles(c context.Context, name string) ([]string,
+	error) {
+	return []string{domain.TenantFrom(c)}, nil
+}
+
+func (f *fakeUsecase) UserAddPermission(c context.Context, username string,
+	permission domain.Permission, period domain.Period, ifNotExists bool) error {
+	return nil
+}
+
+func (f *fakeUsecase) RoleInheritRole(c context.Context, parentName string,
+	childName string, ifNotExists bool) error {
+	return nil
+}
+
+// serve starts the server of main.go on an in-memory listener.
+func serve(t *testing.T, usecase domain.Usecase, authz *middleware.Authorizer,
+	authenticators ...middleware.Authenticator) proto.RbacServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcDelivery.AuthInterceptor(authenticators...),
+			grpcDelivery.TenantInterceptor,
+			grpcDelivery.AuthzInterceptor(authz),
+			grpcDelivery.RevisionInterceptor),
+		grpc.ChainStreamInterceptor(
+			grpcDelivery.AuthStreamInterceptor(authenticators...),
+			grpcDelivery.TenantStreamInterceptor))
+	proto.RegisterRbacServiceServer(s, grpcDelivery.NewDelivery(usecase))
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(c context.Context, _ string) (net.Conn,
+			error) {
+			return lis.DialContext(c)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return proto.NewRbacServiceClient(conn)
+}
+
+func TestAuthentication(t *testing.T) {
+	client := serve(t, &fakeUsecase{}, nil,
+		middleware.NewAPIKeyAuthenticator([]middleware.APIKey{
+			{Key: "reader-key", Subject: "reader",
+				Scopes: []middleware.Scope{middleware.ReadScope}},
+			{Key: "admin-key", Subject: "admin",
+				Scopes: []middleware.Scope{middleware.AdminScope}},
+		}))
+	as := func(key string) context.Context {
+		if key == "" {
+			return context.Background()
+		}
+		return metadata.AppendToOutgoingContext(context.Background(),
+			"x-api-key", key)
+	}
+	check := &proto.UserCheckRequest{Username: "alice", ObjNs: "doc",
+		Relation: "read", ObjName: "readme"}
+
+	tests := []struct {
+		name string
+		key  string
+		call func(c context.Context) error
+		code codes.Code
+	}{
+		{"probe without credentials", "", func(c context.Context) error {
+			_, err := client.Healthy(c, &emptypb.Empty{})
+			return err
+		}, codes.OK},
+		{"check without credentials", "", func(c context.Context) error {
+			_, err := client.UserCheck(c, check)
+			return err
+		}, codes.Unauthenticated},
+		{"schema without credentials", "", func(c context.Context) error {
+			_, err := client.SetSchema(c, &proto.Schema{})
+			return err
+		}, codes.Unauthenticated},
+		{"unknown key", "other-key", func(c context.Context) error {
+			_, err := client.UserCheck(c, check)
+			return err
+		}, codes.Unauthenticated},
+		{"watch without credentials", "", func(c context.Context) error {
+			stream, err := client.Watch(c, &proto.WatchRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.Unauthenticated},
+		{"reader checks", "reader-key", func(c context.Context) error {
+			_, err := client.UserCheck(c, check)
+			return err
+		}, codes.OK},
+		{"reader sets the schema", "reader-key", func(c context.Context) error {
+			_, err := client.SetSchema(c, &proto.Schema{})
+			return err
+		}, codes.PermissionDenied},
+		{"admin sets the schema", "admin-key", func(c context.Context) error {
+			_, err := client.SetSchema(c, &proto.Schema{})
+			return err
+		}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(tt.call(as(tt.key))))
+		})
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	usecase := &fakeUsecase{grants: []string{
+		"alice manage rbac:role/admin",
+		"bob manage rbac:user/carol",
+		"bob manage rbac:object/doc/readme",
+		"root manage rbac:system",
+	}}
+	keys := []middleware.APIKey{}
+	for _, name := range []string{"alice", "bob", "root"} {
+		keys = append(keys, middleware.APIKey{Key: name + "-key", Subject: name,
+			Scopes: []middleware.Scope{middleware.AdminScope}})
+	}
+	client := serve(t, usecase, middleware.NewAuthorizer(usecase, nil),
+		middleware.NewAPIKeyAuthenticator(keys))
+	grant := func(obj *proto.Permission) func(c context.Context) error {
+		return func(c context.Context) error {
+			_, err := client.UserAddPermission(c, &proto.UserPermissionRequest{
+				Username: "carol", Permission: obj})
+			return err
+		}
+	}
+	inherit := func(child string) func(c context.Context) error {
+		return func(c context.Context) error {
+			_, err := client.RoleInheritRole(c, &proto.RoleInheritRequest{
+				ParentName: "admin", ChildName: child})
+			return err
+		}
+	}
+	setSchema := func(c context.Context) error {
+		_, err := client.SetSchema(c, &proto.Schema{})
+		return err
+	}
+
+	tests := []struct {
+		name   string
+		caller string
+		call   func(c context.Context) error
+		code   codes.Code
+	}{
+		{"user manager grants a managed object", "bob",
+			grant(&proto.Permission{Rel: "read", Ns: "doc", Name: "readme"}),
+			codes.OK},
+		{"user manager grants an unmanaged object", "bob",
+			grant(&proto.Permission{Rel: "read", Ns: "doc", Name: "secret"}),
+			codes.PermissionDenied},
+		{"user manager makes the user a system manager", "bob",
+			grant(&proto.Permission{Rel: "manage", Ns: "rbac",
+				Name: "system"}),
+			codes.PermissionDenied},
+		{"role manager inherits an unmanaged role", "alice", inherit("ops"),
+			codes.PermissionDenied},
+		{"role manager inherits itself", "alice", inherit("admin"), codes.OK},
+		{"role manager sets the schema", "alice", setSchema,
+			codes.PermissionDenied},
+		{"system manager sets the schema", "root", setSchema, codes.OK},
+		{"system manager inherits any role", "root", inherit("ops"),
+			codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := metadata.AppendToOutgoingContext(context.Background(),
+				"x-api-key", tt.caller+"-key")
+			assert.Equal(t, tt.code, status.Code(tt.call(c)))
+		})
+	}
+}
+
+func TestTenant(t *testing.T) {
+	client := serve(t, &fakeUsecase{}, nil,
+		middleware.NewAPIKeyAuthenticator([]middleware.APIKey{
+			{Key: "operator-key", Subject: "operator",
+				Scopes: []middleware.Scope{middleware.AdminScope}},
+			{Key: "acme-key", Subject: "acme-ci", Tenant: "acme",
+				Scopes: []middleware.Scope{middleware.AdminScope}},
+		}))
+
+	tests := []struct {
+		name   string
+		key    string
+		tenant string
+		code   codes.Code
+		want   string
+	}{
+		{"default", "operator-key", "", codes.OK, ""},
+		{"metadata", "operator-key", "globex", codes.OK, "globex"},
+		{"invalid", "operator-key", "acme corp", codes.InvalidArgument, ""},
+		{"bound principal", "acme-key", "", codes.OK, "acme"},
+		{"bound principal elsewhere", "acme-key", "globex",
+			codes.PermissionDenied, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := metadata.AppendToOutgoingContext(context.Background(),
+				"x-api-key", tt.key)
+			if tt.tenant != "" {
+				c = metadata.AppendToOutgoingContext(c, "x-tenant", tt.tenant)
+			}
+			res, err := client.UserGetRoles(c, &proto.NameRequest{Name: "x"})
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, []string{tt.want}, res.GetNames())
+				return
+			}
+
+			stream, err := client.Watch(c, &proto.WatchRequest{})
+			if err == nil {
+				_, err = stream.Recv()
+			}
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
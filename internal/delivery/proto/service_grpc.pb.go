@@ -0,0 +1,1361 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: internal/delivery/proto/service.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RbacService_Healthy_FullMethodName                = "/rbac.RbacService/Healthy"
+	RbacService_DeleteUser_FullMethodName             = "/rbac.RbacService/DeleteUser"
+	RbacService_UserGetPermissions_FullMethodName     = "/rbac.RbacService/UserGetPermissions"
+	RbacService_UserGetRoles_FullMethodName           = "/rbac.RbacService/UserGetRoles"
+	RbacService_UserCheck_FullMethodName              = "/rbac.RbacService/UserCheck"
+	RbacService_UserExplain_FullMethodName            = "/rbac.RbacService/UserExplain"
+	RbacService_BulkCheck_FullMethodName              = "/rbac.RbacService/BulkCheck"
+	RbacService_UserAddPermission_FullMethodName      = "/rbac.RbacService/UserAddPermission"
+	RbacService_UserRemovePermission_FullMethodName   = "/rbac.RbacService/UserRemovePermission"
+	RbacService_UserAddDeny_FullMethodName            = "/rbac.RbacService/UserAddDeny"
+	RbacService_UserRemoveDeny_FullMethodName         = "/rbac.RbacService/UserRemoveDeny"
+	RbacService_UserAddRole_FullMethodName            = "/rbac.RbacService/UserAddRole"
+	RbacService_UserRemoveRole_FullMethodName         = "/rbac.RbacService/UserRemoveRole"
+	RbacService_DeleteRole_FullMethodName             = "/rbac.RbacService/DeleteRole"
+	RbacService_RoleGetUsers_FullMethodName           = "/rbac.RbacService/RoleGetUsers"
+	RbacService_RoleGetPermissions_FullMethodName     = "/rbac.RbacService/RoleGetPermissions"
+	RbacService_RoleAddPermission_FullMethodName      = "/rbac.RbacService/RoleAddPermission"
+	RbacService_RoleRemovePermission_FullMethodName   = "/rbac.RbacService/RoleRemovePermission"
+	RbacService_RoleAddDeny_FullMethodName            = "/rbac.RbacService/RoleAddDeny"
+	RbacService_RoleRemoveDeny_FullMethodName         = "/rbac.RbacService/RoleRemoveDeny"
+	RbacService_RoleInheritRole_FullMethodName        = "/rbac.RbacService/RoleInheritRole"
+	RbacService_RoleUnInheritRole_FullMethodName      = "/rbac.RbacService/RoleUnInheritRole"
+	RbacService_RoleGetChildRole_FullMethodName       = "/rbac.RbacService/RoleGetChildRole"
+	RbacService_RoleGetParentRole_FullMethodName      = "/rbac.RbacService/RoleGetParentRole"
+	RbacService_DeleteObject_FullMethodName           = "/rbac.RbacService/DeleteObject"
+	RbacService_WhichRoleHasPermission_FullMethodName = "/rbac.RbacService/WhichRoleHasPermission"
+	RbacService_WhichUserHasPermission_FullMethodName = "/rbac.RbacService/WhichUserHasPermission"
+	RbacService_FindCycles_FullMethodName             = "/rbac.RbacService/FindCycles"
+	RbacService_ApplyOperations_FullMethodName        = "/rbac.RbacService/ApplyOperations"
+	RbacService_GetSchema_FullMethodName              = "/rbac.RbacService/GetSchema"
+	RbacService_SetSchema_FullMethodName              = "/rbac.RbacService/SetSchema"
+	RbacService_ExportTenant_FullMethodName           = "/rbac.RbacService/ExportTenant"
+	RbacService_PurgeTenant_FullMethodName            = "/rbac.RbacService/PurgeTenant"
+	RbacService_Watch_FullMethodName                  = "/rbac.RbacService/Watch"
+)
+
+// RbacServiceClient is the client API for RbacService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RbacServiceClient interface {
+	Healthy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteUser(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UserGetPermissions(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*PermissionsResponse, error)
+	UserGetRoles(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error)
+	UserCheck(ctx context.Context, in *UserCheckRequest, opts ...grpc.CallOption) (*UserCheckResponse, error)
+	UserExplain(ctx context.Context, in *UserExplainRequest, opts ...grpc.CallOption) (*Explanation, error)
+	BulkCheck(ctx context.Context, in *BulkCheckRequest, opts ...grpc.CallOption) (*BulkCheckResponse, error)
+	UserAddPermission(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UserRemovePermission(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UserAddDeny(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UserRemoveDeny(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UserAddRole(ctx context.Context, in *UserRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UserRemoveRole(ctx context.Context, in *UserRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteRole(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RoleGetUsers(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error)
+	RoleGetPermissions(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*PermissionsResponse, error)
+	RoleAddPermission(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RoleRemovePermission(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RoleAddDeny(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RoleRemoveDeny(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RoleInheritRole(ctx context.Context, in *RoleInheritRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RoleUnInheritRole(ctx context.Context, in *RoleInheritRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RoleGetChildRole(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error)
+	RoleGetParentRole(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error)
+	DeleteObject(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	WhichRoleHasPermission(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*NamesResponse, error)
+	WhichUserHasPermission(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*NamesResponse, error)
+	FindCycles(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FindCyclesResponse, error)
+	ApplyOperations(ctx context.Context, in *ApplyOperationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Schema, error)
+	SetSchema(ctx context.Context, in *Schema, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// The tenant of every rpc is read from the x-tenant metadata.
+	ExportTenant(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EdgesResponse, error)
+	PurgeTenant(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (RbacService_WatchClient, error)
+}
+
+type rbacServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRbacServiceClient(cc grpc.ClientConnInterface) RbacServiceClient {
+	return &rbacServiceClient{cc}
+}
+
+func (c *rbacServiceClient) Healthy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_Healthy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) DeleteUser(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_DeleteUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserGetPermissions(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*PermissionsResponse, error) {
+	out := new(PermissionsResponse)
+	err := c.cc.Invoke(ctx, RbacService_UserGetPermissions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserGetRoles(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error) {
+	out := new(NamesResponse)
+	err := c.cc.Invoke(ctx, RbacService_UserGetRoles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserCheck(ctx context.Context, in *UserCheckRequest, opts ...grpc.CallOption) (*UserCheckResponse, error) {
+	out := new(UserCheckResponse)
+	err := c.cc.Invoke(ctx, RbacService_UserCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserExplain(ctx context.Context, in *UserExplainRequest, opts ...grpc.CallOption) (*Explanation, error) {
+	out := new(Explanation)
+	err := c.cc.Invoke(ctx, RbacService_UserExplain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) BulkCheck(ctx context.Context, in *BulkCheckRequest, opts ...grpc.CallOption) (*BulkCheckResponse, error) {
+	out := new(BulkCheckResponse)
+	err := c.cc.Invoke(ctx, RbacService_BulkCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserAddPermission(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_UserAddPermission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserRemovePermission(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_UserRemovePermission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserAddDeny(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_UserAddDeny_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserRemoveDeny(ctx context.Context, in *UserPermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_UserRemoveDeny_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserAddRole(ctx context.Context, in *UserRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_UserAddRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) UserRemoveRole(ctx context.Context, in *UserRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_UserRemoveRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) DeleteRole(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_DeleteRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleGetUsers(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error) {
+	out := new(NamesResponse)
+	err := c.cc.Invoke(ctx, RbacService_RoleGetUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleGetPermissions(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*PermissionsResponse, error) {
+	out := new(PermissionsResponse)
+	err := c.cc.Invoke(ctx, RbacService_RoleGetPermissions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleAddPermission(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_RoleAddPermission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleRemovePermission(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_RoleRemovePermission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleAddDeny(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_RoleAddDeny_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleRemoveDeny(ctx context.Context, in *RolePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_RoleRemoveDeny_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleInheritRole(ctx context.Context, in *RoleInheritRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_RoleInheritRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleUnInheritRole(ctx context.Context, in *RoleInheritRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_RoleUnInheritRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleGetChildRole(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error) {
+	out := new(NamesResponse)
+	err := c.cc.Invoke(ctx, RbacService_RoleGetChildRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) RoleGetParentRole(ctx context.Context, in *NameRequest, opts ...grpc.CallOption) (*NamesResponse, error) {
+	out := new(NamesResponse)
+	err := c.cc.Invoke(ctx, RbacService_RoleGetParentRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) DeleteObject(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_DeleteObject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) WhichRoleHasPermission(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*NamesResponse, error) {
+	out := new(NamesResponse)
+	err := c.cc.Invoke(ctx, RbacService_WhichRoleHasPermission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) WhichUserHasPermission(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*NamesResponse, error) {
+	out := new(NamesResponse)
+	err := c.cc.Invoke(ctx, RbacService_WhichUserHasPermission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) FindCycles(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FindCyclesResponse, error) {
+	out := new(FindCyclesResponse)
+	err := c.cc.Invoke(ctx, RbacService_FindCycles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) ApplyOperations(ctx context.Context, in *ApplyOperationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_ApplyOperations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) GetSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Schema, error) {
+	out := new(Schema)
+	err := c.cc.Invoke(ctx, RbacService_GetSchema_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) SetSchema(ctx context.Context, in *Schema, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_SetSchema_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) ExportTenant(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EdgesResponse, error) {
+	out := new(EdgesResponse)
+	err := c.cc.Invoke(ctx, RbacService_ExportTenant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) PurgeTenant(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RbacService_PurgeTenant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (RbacService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RbacService_ServiceDesc.Streams[0], RbacService_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rbacServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RbacService_WatchClient interface {
+	Recv() (*Change, error)
+	grpc.ClientStream
+}
+
+type rbacServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *rbacServiceWatchClient) Recv() (*Change, error) {
+	m := new(Change)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RbacServiceServer is the server API for RbacService service.
+// All implementations must embed UnimplementedRbacServiceServer
+// for forward compatibility
+type RbacServiceServer interface {
+	Healthy(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	DeleteUser(context.Context, *NameRequest) (*emptypb.Empty, error)
+	UserGetPermissions(context.Context, *NameRequest) (*PermissionsResponse, error)
+	UserGetRoles(context.Context, *NameRequest) (*NamesResponse, error)
+	UserCheck(context.Context, *UserCheckRequest) (*UserCheckResponse, error)
+	UserExplain(context.Context, *UserExplainRequest) (*Explanation, error)
+	BulkCheck(context.Context, *BulkCheckRequest) (*BulkCheckResponse, error)
+	UserAddPermission(context.Context, *UserPermissionRequest) (*emptypb.Empty, error)
+	UserRemovePermission(context.Context, *UserPermissionRequest) (*emptypb.Empty, error)
+	UserAddDeny(context.Context, *UserPermissionRequest) (*emptypb.Empty, error)
+	UserRemoveDeny(context.Context, *UserPermissionRequest) (*emptypb.Empty, error)
+	UserAddRole(context.Context, *UserRoleRequest) (*emptypb.Empty, error)
+	UserRemoveRole(context.Context, *UserRoleRequest) (*emptypb.Empty, error)
+	DeleteRole(context.Context, *NameRequest) (*emptypb.Empty, error)
+	RoleGetUsers(context.Context, *NameRequest) (*NamesResponse, error)
+	RoleGetPermissions(context.Context, *NameRequest) (*PermissionsResponse, error)
+	RoleAddPermission(context.Context, *RolePermissionRequest) (*emptypb.Empty, error)
+	RoleRemovePermission(context.Context, *RolePermissionRequest) (*emptypb.Empty, error)
+	RoleAddDeny(context.Context, *RolePermissionRequest) (*emptypb.Empty, error)
+	RoleRemoveDeny(context.Context, *RolePermissionRequest) (*emptypb.Empty, error)
+	RoleInheritRole(context.Context, *RoleInheritRequest) (*emptypb.Empty, error)
+	RoleUnInheritRole(context.Context, *RoleInheritRequest) (*emptypb.Empty, error)
+	RoleGetChildRole(context.Context, *NameRequest) (*NamesResponse, error)
+	RoleGetParentRole(context.Context, *NameRequest) (*NamesResponse, error)
+	DeleteObject(context.Context, *ObjectRequest) (*emptypb.Empty, error)
+	WhichRoleHasPermission(context.Context, *ObjectRequest) (*NamesResponse, error)
+	WhichUserHasPermission(context.Context, *ObjectRequest) (*NamesResponse, error)
+	FindCycles(context.Context, *emptypb.Empty) (*FindCyclesResponse, error)
+	ApplyOperations(context.Context, *ApplyOperationsRequest) (*emptypb.Empty, error)
+	GetSchema(context.Context, *emptypb.Empty) (*Schema, error)
+	SetSchema(context.Context, *Schema) (*emptypb.Empty, error)
+	// The tenant of every rpc is read from the x-tenant metadata.
+	ExportTenant(context.Context, *emptypb.Empty) (*EdgesResponse, error)
+	PurgeTenant(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	Watch(*WatchRequest, RbacService_WatchServer) error
+	mustEmbedUnimplementedRbacServiceServer()
+}
+
+// UnimplementedRbacServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRbacServiceServer struct {
+}
+
+func (UnimplementedRbacServiceServer) Healthy(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Healthy not implemented")
+}
+func (UnimplementedRbacServiceServer) DeleteUser(context.Context, *NameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedRbacServiceServer) UserGetPermissions(context.Context, *NameRequest) (*PermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetPermissions not implemented")
+}
+func (UnimplementedRbacServiceServer) UserGetRoles(context.Context, *NameRequest) (*NamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetRoles not implemented")
+}
+func (UnimplementedRbacServiceServer) UserCheck(context.Context, *UserCheckRequest) (*UserCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserCheck not implemented")
+}
+func (UnimplementedRbacServiceServer) UserExplain(context.Context, *UserExplainRequest) (*Explanation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserExplain not implemented")
+}
+func (UnimplementedRbacServiceServer) BulkCheck(context.Context, *BulkCheckRequest) (*BulkCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCheck not implemented")
+}
+func (UnimplementedRbacServiceServer) UserAddPermission(context.Context, *UserPermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAddPermission not implemented")
+}
+func (UnimplementedRbacServiceServer) UserRemovePermission(context.Context, *UserPermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRemovePermission not implemented")
+}
+func (UnimplementedRbacServiceServer) UserAddDeny(context.Context, *UserPermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAddDeny not implemented")
+}
+func (UnimplementedRbacServiceServer) UserRemoveDeny(context.Context, *UserPermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRemoveDeny not implemented")
+}
+func (UnimplementedRbacServiceServer) UserAddRole(context.Context, *UserRoleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAddRole not implemented")
+}
+func (UnimplementedRbacServiceServer) UserRemoveRole(context.Context, *UserRoleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRemoveRole not implemented")
+}
+func (UnimplementedRbacServiceServer) DeleteRole(context.Context, *NameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRole not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleGetUsers(context.Context, *NameRequest) (*NamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleGetUsers not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleGetPermissions(context.Context, *NameRequest) (*PermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleGetPermissions not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleAddPermission(context.Context, *RolePermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleAddPermission not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleRemovePermission(context.Context, *RolePermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleRemovePermission not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleAddDeny(context.Context, *RolePermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleAddDeny not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleRemoveDeny(context.Context, *RolePermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleRemoveDeny not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleInheritRole(context.Context, *RoleInheritRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleInheritRole not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleUnInheritRole(context.Context, *RoleInheritRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleUnInheritRole not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleGetChildRole(context.Context, *NameRequest) (*NamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleGetChildRole not implemented")
+}
+func (UnimplementedRbacServiceServer) RoleGetParentRole(context.Context, *NameRequest) (*NamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleGetParentRole not implemented")
+}
+func (UnimplementedRbacServiceServer) DeleteObject(context.Context, *ObjectRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObject not implemented")
+}
+func (UnimplementedRbacServiceServer) WhichRoleHasPermission(context.Context, *ObjectRequest) (*NamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhichRoleHasPermission not implemented")
+}
+func (UnimplementedRbacServiceServer) WhichUserHasPermission(context.Context, *ObjectRequest) (*NamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhichUserHasPermission not implemented")
+}
+func (UnimplementedRbacServiceServer) FindCycles(context.Context, *emptypb.Empty) (*FindCyclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindCycles not implemented")
+}
+func (UnimplementedRbacServiceServer) ApplyOperations(context.Context, *ApplyOperationsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyOperations not implemented")
+}
+func (UnimplementedRbacServiceServer) GetSchema(context.Context, *emptypb.Empty) (*Schema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchema not implemented")
+}
+func (UnimplementedRbacServiceServer) SetSchema(context.Context, *Schema) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSchema not implemented")
+}
+func (UnimplementedRbacServiceServer) ExportTenant(context.Context, *emptypb.Empty) (*EdgesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTenant not implemented")
+}
+func (UnimplementedRbacServiceServer) PurgeTenant(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeTenant not implemented")
+}
+func (UnimplementedRbacServiceServer) Watch(*WatchRequest, RbacService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedRbacServiceServer) mustEmbedUnimplementedRbacServiceServer() {}
+
+// UnsafeRbacServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RbacServiceServer will
+// result in compilation errors.
+type UnsafeRbacServiceServer interface {
+	mustEmbedUnimplementedRbacServiceServer()
+}
+
+func RegisterRbacServiceServer(s grpc.ServiceRegistrar, srv RbacServiceServer) {
+	s.RegisterService(&RbacService_ServiceDesc, srv)
+}
+
+func _RbacService_Healthy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).Healthy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_Healthy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).Healthy(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).DeleteUser(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserGetPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserGetPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserGetPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserGetPermissions(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserGetRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserGetRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserGetRoles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserGetRoles(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserCheck(ctx, req.(*UserCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserExplain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserExplain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserExplain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserExplain(ctx, req.(*UserExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_BulkCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).BulkCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_BulkCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).BulkCheck(ctx, req.(*BulkCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserAddPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserAddPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserAddPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserAddPermission(ctx, req.(*UserPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserRemovePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserRemovePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserRemovePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserRemovePermission(ctx, req.(*UserPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserAddDeny_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserAddDeny(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserAddDeny_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserAddDeny(ctx, req.(*UserPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserRemoveDeny_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserRemoveDeny(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserRemoveDeny_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserRemoveDeny(ctx, req.(*UserPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserAddRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserAddRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserAddRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserAddRole(ctx, req.(*UserRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_UserRemoveRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).UserRemoveRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_UserRemoveRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).UserRemoveRole(ctx, req.(*UserRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_DeleteRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).DeleteRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_DeleteRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).DeleteRole(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleGetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleGetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleGetUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleGetUsers(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleGetPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleGetPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleGetPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleGetPermissions(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleAddPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RolePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleAddPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleAddPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleAddPermission(ctx, req.(*RolePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleRemovePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RolePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleRemovePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleRemovePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleRemovePermission(ctx, req.(*RolePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleAddDeny_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RolePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleAddDeny(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleAddDeny_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleAddDeny(ctx, req.(*RolePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleRemoveDeny_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RolePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleRemoveDeny(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleRemoveDeny_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleRemoveDeny(ctx, req.(*RolePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleInheritRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleInheritRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleInheritRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleInheritRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleInheritRole(ctx, req.(*RoleInheritRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleUnInheritRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleInheritRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleUnInheritRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleUnInheritRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleUnInheritRole(ctx, req.(*RoleInheritRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleGetChildRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleGetChildRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleGetChildRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleGetChildRole(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_RoleGetParentRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).RoleGetParentRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_RoleGetParentRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).RoleGetParentRole(ctx, req.(*NameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_DeleteObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).DeleteObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_DeleteObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).DeleteObject(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_WhichRoleHasPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).WhichRoleHasPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_WhichRoleHasPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).WhichRoleHasPermission(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_WhichUserHasPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).WhichUserHasPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_WhichUserHasPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).WhichUserHasPermission(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_FindCycles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).FindCycles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_FindCycles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).FindCycles(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_ApplyOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).ApplyOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_ApplyOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).ApplyOperations(ctx, req.(*ApplyOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_GetSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).GetSchema(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_SetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Schema)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).SetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_SetSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).SetSchema(ctx, req.(*Schema))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_ExportTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).ExportTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_ExportTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).ExportTenant(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_PurgeTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacServiceServer).PurgeTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RbacService_PurgeTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacServiceServer).PurgeTenant(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RbacServiceServer).Watch(m, &rbacServiceWatchServer{stream})
+}
+
+type RbacService_WatchServer interface {
+	Send(*Change) error
+	grpc.ServerStream
+}
+
+type rbacServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *rbacServiceWatchServer) Send(m *Change) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RbacService_ServiceDesc is the grpc.ServiceDesc for RbacService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RbacService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rbac.RbacService",
+	HandlerType: (*RbacServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Healthy",
+			Handler:    _RbacService_Healthy_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _RbacService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "UserGetPermissions",
+			Handler:    _RbacService_UserGetPermissions_Handler,
+		},
+		{
+			MethodName: "UserGetRoles",
+			Handler:    _RbacService_UserGetRoles_Handler,
+		},
+		{
+			MethodName: "UserCheck",
+			Handler:    _RbacService_UserCheck_Handler,
+		},
+		{
+			MethodName: "UserExplain",
+			Handler:    _RbacService_UserExplain_Handler,
+		},
+		{
+			MethodName: "BulkCheck",
+			Handler:    _RbacService_BulkCheck_Handler,
+		},
+		{
+			MethodName: "UserAddPermission",
+			Handler:    _RbacService_UserAddPermission_Handler,
+		},
+		{
+			MethodName: "UserRemovePermission",
+			Handler:    _RbacService_UserRemovePermission_Handler,
+		},
+		{
+			MethodName: "UserAddDeny",
+			Handler:    _RbacService_UserAddDeny_Handler,
+		},
+		{
+			MethodName: "UserRemoveDeny",
+			Handler:    _RbacService_UserRemoveDeny_Handler,
+		},
+		{
+			MethodName: "UserAddRole",
+			Handler:    _RbacService_UserAddRole_Handler,
+		},
+		{
+			MethodName: "UserRemoveRole",
+			Handler:    _RbacService_UserRemoveRole_Handler,
+		},
+		{
+			MethodName: "DeleteRole",
+			Handler:    _RbacService_DeleteRole_Handler,
+		},
+		{
+			MethodName: "RoleGetUsers",
+			Handler:    _RbacService_RoleGetUsers_Handler,
+		},
+		{
+			MethodName: "RoleGetPermissions",
+			Handler:    _RbacService_RoleGetPermissions_Handler,
+		},
+		{
+			MethodName: "RoleAddPermission",
+			Handler:    _RbacService_RoleAddPermission_Handler,
+		},
+		{
+			MethodName: "RoleRemovePermission",
+			Handler:    _RbacService_RoleRemovePermission_Handler,
+		},
+		{
+			MethodName: "RoleAddDeny",
+			Handler:    _RbacService_RoleAddDeny_Handler,
+		},
+		{
+			MethodName: "RoleRemoveDeny",
+			Handler:    _RbacService_RoleRemoveDeny_Handler,
+		},
+		{
+			MethodName: "RoleInheritRole",
+			Handler:    _RbacService_RoleInheritRole_Handler,
+		},
+		{
+			MethodName: "RoleUnInheritRole",
+			Handler:    _RbacService_RoleUnInheritRole_Handler,
+		},
+		{
+			MethodName: "RoleGetChildRole",
+			Handler:    _RbacService_RoleGetChildRole_Handler,
+		},
+		{
+			MethodName: "RoleGetParentRole",
+			Handler:    _RbacService_RoleGetParentRole_Handler,
+		},
+		{
+			MethodName: "DeleteObject",
+			Handler:    _RbacService_DeleteObject_Handler,
+		},
+		{
+			MethodName: "WhichRoleHasPermission",
+			Handler:    _RbacService_WhichRoleHasPermission_Handler,
+		},
+		{
+			MethodName: "WhichUserHasPermission",
+			Handler:    _RbacService_WhichUserHasPermission_Handler,
+		},
+		{
+			MethodName: "FindCycles",
+			Handler:    _RbacService_FindCycles_Handler,
+		},
+		{
+			MethodName: "ApplyOperations",
+			Handler:    _RbacService_ApplyOperations_Handler,
+		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _RbacService_GetSchema_Handler,
+		},
+		{
+			MethodName: "SetSchema",
+			Handler:    _RbacService_SetSchema_Handler,
+		},
+		{
+			MethodName: "ExportTenant",
+			Handler:    _RbacService_ExportTenant_Handler,
+		},
+		{
+			MethodName: "PurgeTenant",
+			Handler:    _RbacService_PurgeTenant_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _RbacService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/delivery/proto/service.proto",
+}
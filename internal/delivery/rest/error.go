@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// fail translates the error into its status code and writes the error body.
+func (d *RestDelivery) fail(c *gin.Context, err error) {
+	status, code := http.StatusInternalServerError, "internal"
+	switch {
+	case errors.Is(err, domain.ErrBodyAttribute):
+		status, code = http.StatusBadRequest, "invalid_argument"
+	case errors.Is(err, domain.ErrRecordNotFound):
+		status, code = http.StatusNotFound, "not_found"
+	case errors.Is(err, domain.ErrDuplicateRecord):
+		status, code = http.StatusConflict, "already_exists"
+	case errors.Is(err, domain.ErrGraphCycle):
+		status, code = http.StatusConflict, "graph_cycle"
+	case errors.Is(err, domain.ErrTokenExpired):
+		status, code = http.StatusGone, "token_expired"
+	case errors.Is(err, domain.ErrNotImplemented):
+		status, code = http.StatusNotImplemented, "not_implemented"
+	case errors.Is(err, domain.ErrUnavailable),
+		errors.Is(err, context.DeadlineExceeded):
+		status, code = http.StatusServiceUnavailable, "unavailable"
+	}
+	c.AbortWithStatusJSON(status, domain.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+		Details: details(err),
+	})
+}
+
+// details lists the messages the error was wrapped with, outermost first, so
+// that clients get e.g. the offending field without parsing the message.
+func details(err error) []string {
+	chain := errors.Unpack(err).ErrChain
+	res := make([]string, len(chain))
+	for i, link := range chain {
+		res[len(chain)-1-i] = link.Msg
+	}
+	return res
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"net/http"
+
+	errors "github.com/rotisserie/eris"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+type APIKey struct {
+	Key     string  `mapstructure:"key"`
+	Subject string  `mapstructure:"subject"`
+	Scopes  []Scope `mapstructure:"scopes"`
+	Tenant  string  `mapstructure:"tenant"`
+}
+
+// APIKeyAuthenticator accepts the static keys sent in the X-API-Key header.
+type APIKeyAuthenticator struct {
+	// keyed by the hash so that the lookup time tells nothing about the key
+	keys map[[sha256.Size]byte]Principal
+}
+
+func NewAPIKeyAuthenticator(keys []APIKey) *APIKeyAuthenticator {
+	a := &APIKeyAuthenticator{keys: map[[sha256.Size]byte]Principal{}}
+	for _, k := range keys {
+		a.keys[sha256.Sum256([]byte(k.Key))] = Principal{
+			Subject: k.Subject,
+			Scopes:  k.Scopes,
+			Tenant:  k.Tenant,
+		}
+	}
+	return a
+}
+
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		return Principal{}, ErrNoCredentials
+	}
+	principal, ok := a.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return Principal{}, errors.New("unknown api key")
+	}
+	return principal, nil
+}
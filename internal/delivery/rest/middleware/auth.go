@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// Scope is what a caller is allowed to do with the API, each scope includes
+// the ones before it.
+type Scope string
+
+const (
+	ReadScope  Scope = "read"
+	WriteScope Scope = "write"
+	AdminScope Scope = "admin"
+)
+
+var scopes = []Scope{ReadScope, WriteScope, AdminScope}
+
+// ValidScope reports whether s is one of the known scopes.
+func ValidScope(s Scope) bool {
+	return slices.Contains(scopes, s)
+}
+
+// Principal is the authenticated caller of a request, one with a Tenant can
+// only reach that tenant.
+type Principal struct {
+	Subject string
+	Scopes  []Scope
+	Tenant  string
+}
+
+func (p Principal) Has(scope Scope) bool {
+	required := slices.Index(scopes, scope)
+	for _, s := range p.Scopes {
+		if i := slices.Index(scopes, s); i >= 0 && i >= required {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNoCredentials is returned by an Authenticator when the request carries
+// none of the credentials it understands, so that the next one is tried.
+var ErrNoCredentials = errors.New("no credentials")
+
+type Authenticator interface {
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+const principalKey = "principal"
+
+// Auth identifies the caller with the first authenticator that recognizes
+// the credentials of the request, invalid credentials are rejected right
+// away. Without authenticators every caller is an anonymous admin.
+func Auth(authenticators ...Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(authenticators) == 0 {
+			c.Set(principalKey, Principal{Scopes: []Scope{AdminScope}})
+			c.Next()
+			return
+		}
+		principal, err := Identify(c.Request, authenticators...)
+		if err != nil && !errors.Is(err, ErrNoCredentials) {
+			unauthenticated(c, err)
+			return
+		}
+		if err == nil {
+			c.Set(principalKey, principal)
+		}
+		c.Next()
+	}
+}
+
+// Identify returns the caller of the first authenticator that recognizes the
+// credentials of r, ErrNoCredentials when none does.
+func Identify(r *http.Request, authenticators ...Authenticator) (Principal,
+	error) {
+	for _, a := range authenticators {
+		principal, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+	return Principal{}, ErrNoCredentials
+}
+
+// RequireScope rejects the requests whose caller was not granted scope.
+func RequireScope(scope Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFrom(c)
+		if !ok {
+			unauthenticated(c, ErrNoCredentials)
+			return
+		}
+		if !principal.Has(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, domain.ErrorResponse{
+				Code:    "permission_denied",
+				Message: "scope " + string(scope) + " is required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// PrincipalFrom returns the caller identified by Auth.
+func PrincipalFrom(c *gin.Context) (Principal, bool) {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return Principal{}, false
+	}
+	principal, ok := v.(Principal)
+	return principal, ok
+}
+
+func unauthenticated(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer realm="rbac-server"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, domain.ErrorResponse{
+		Code:    "unauthenticated",
+		Message: err.Error(),
+	})
+}
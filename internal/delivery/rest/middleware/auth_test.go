@@ -0,0 +1,199 @@
+package middleware_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func marshal(v any) []byte {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+func segment(v any) string {
+	return base64.RawURLEncoding.EncodeToString(marshal(v))
+}
+
+// token builds a JWT, sign returns the signature of the signing input.
+func token(header map[string]string, claims map[string]any,
+	sign func(signed []byte) []byte) string {
+	signed := segment(header) + "." + segment(claims)
+	return signed + "." +
+		base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func hs256(signed []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(signed)
+	return mac.Sum(nil)
+}
+
+func claims(scope string, ttl time.Duration) map[string]any {
+	return map[string]any{
+		"sub":   "alice",
+		"aud":   []string{"rbac"},
+		"scope": scope,
+		"exp":   time.Now().Add(ttl).Unix(),
+	}
+}
+
+func TestAuth(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	jwks := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(jwks, marshal(map[string]any{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "n": b64(rsaKey.N.Bytes()),
+				"e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec", "crv": "P-256",
+				"x": b64(ecKey.X.FillBytes(make([]byte, 32))),
+				"y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+		},
+	}), 0o644))
+	rs256 := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		sig, _ := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		return sig
+	}
+	es256 := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		r, s, _ := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	opts := middleware.TokenOptions{Audience: "rbac"}
+	jwksAuth, err := middleware.NewJWKSAuthenticator(jwks, opts)
+	assert.NoError(t, err)
+	r := gin.New()
+	r.Use(middleware.Auth(
+		middleware.NewAPIKeyAuthenticator([]middleware.APIKey{
+			{Key: "reader-key", Subject: "dashboard",
+				Scopes: []middleware.Scope{middleware.ReadScope}},
+			{Key: "admin-key", Subject: "ops",
+				Scopes: []middleware.Scope{middleware.AdminScope}},
+		}),
+		middleware.NewHMACAuthenticator([]byte(secret), opts),
+		jwksAuth,
+	))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/read", middleware.RequireScope(middleware.ReadScope), ok)
+	r.POST("/write", middleware.RequireScope(middleware.WriteScope), ok)
+
+	hs := map[string]string{"alg": "HS256"}
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		value  string
+		status int
+	}{
+		{"no credentials", http.MethodGet, "/read", "", "",
+			http.StatusUnauthorized},
+		{"unknown api key", http.MethodGet, "/read", "X-API-Key", "guess",
+			http.StatusUnauthorized},
+		{"read key reads", http.MethodGet, "/read", "X-API-Key", "reader-key",
+			http.StatusOK},
+		{"read key writes", http.MethodPost, "/write", "X-API-Key",
+			"reader-key", http.StatusForbidden},
+		{"admin key writes", http.MethodPost, "/write", "X-API-Key",
+			"admin-key", http.StatusOK},
+		{"hmac token", http.MethodPost, "/write", "Authorization",
+			"Bearer " + token(hs, claims("write", time.Minute), hs256),
+			http.StatusOK},
+		{"hmac token without scope", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(hs, claims("", time.Minute), hs256),
+			http.StatusForbidden},
+		{"expired hmac token", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(hs, claims("read", -time.Minute), hs256),
+			http.StatusUnauthorized},
+		{"tampered hmac token", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(hs, claims("read", time.Minute),
+				func(signed []byte) []byte { return hs256(append(signed, '!')) }),
+			http.StatusUnauthorized},
+		{"fractional dates", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(hs, map[string]any{"sub": "alice", "aud": "rbac",
+				"scope": "read",
+				"nbf":   float64(time.Now().Add(-time.Minute).Unix()) + 0.25,
+				"exp":   float64(time.Now().Add(time.Minute).Unix()) + 0.5},
+				hs256),
+			http.StatusOK},
+		{"fractional exp passed", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(hs, map[string]any{"sub": "alice", "aud": "rbac",
+				"scope": "read",
+				"exp":   float64(time.Now().Add(-time.Minute).Unix()) + 0.5},
+				hs256),
+			http.StatusUnauthorized},
+		{"hmac token without exp", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(hs, map[string]any{"sub": "alice", "aud": "rbac",
+				"scope": "read"}, hs256),
+			http.StatusUnauthorized},
+		{"wrong audience", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(hs, map[string]any{"sub": "alice", "aud": "other",
+				"scope": "read", "exp": time.Now().Add(time.Minute).Unix()},
+				hs256),
+			http.StatusUnauthorized},
+		{"unsigned token", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(map[string]string{"alg": "none"},
+				claims("admin", time.Minute),
+				func([]byte) []byte { return nil }),
+			http.StatusUnauthorized},
+		{"rsa token", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(map[string]string{"alg": "RS256", "kid": "rsa"},
+				claims("read", time.Minute), rs256),
+			http.StatusOK},
+		{"ec token", http.MethodPost, "/write", "Authorization",
+			"Bearer " + token(map[string]string{"alg": "ES256", "kid": "ec"},
+				claims("admin", time.Minute), es256),
+			http.StatusOK},
+		{"token of the wrong key", http.MethodGet, "/read", "Authorization",
+			"Bearer " + token(map[string]string{"alg": "RS256", "kid": "ec"},
+				claims("read", time.Minute), rs256),
+			http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestAuthDisabled(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Auth())
+	r.DELETE("/", middleware.RequireScope(middleware.AdminScope),
+		func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
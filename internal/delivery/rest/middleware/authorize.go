@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+const (
+	// AuthzNs is the namespace of the objects standing for the server's own
+	// resources, e.g. rbac:role/admin.
+	AuthzNs = "rbac"
+	// AuthzRel is the relation required on them.
+	AuthzRel = "manage"
+	// SystemObject covers every resource of the server.
+	SystemObject = "system"
+)
+
+// TargetObject is the rbac object of the object ns:name, the one granted or
+// denied by a change. The objects of the rbac namespace grant the management
+// of the server itself, so only the managers of rbac:system may touch them.
+// The namespace is escaped as a path segment, which keeps the valid ones
+// as they are, so that ns:name never shares its object with another pair.
+func TargetObject(ns string, name string) string {
+	if ns == AuthzNs {
+		return SystemObject
+	}
+	return "object/" + url.PathEscape(ns) + "/" + name
+}
+
+// Authorizer protects the management routes with the graph the server
+// serves: the caller needs manage on the rbac objects of the route, or on
+// rbac:system.
+type Authorizer struct {
+	usecase     domain.Usecase
+	superAdmins []string
+}
+
+// NewAuthorizer returns an Authorizer, the super admins bypass the checks so
+// that the first grants can be written.
+func NewAuthorizer(usecase domain.Usecase, superAdmins []string) *Authorizer {
+	return &Authorizer{usecase: usecase, superAdmins: superAdmins}
+}
+
+// Authorize returns the first of the objects the principal cannot manage, or
+// "" if it can manage all of them.
+func (a *Authorizer) Authorize(c context.Context, principal Principal,
+	objects ...string) (string, error) {
+	if slices.Contains(a.superAdmins, principal.Subject) {
+		return "", nil
+	}
+	for _, name := range objects {
+		ok := false
+		for _, obj := range slices.Compact([]string{name, SystemObject}) {
+			var err error
+			ok, err = a.usecase.UserCheck(c, principal.Subject, AuthzNs,
+				AuthzRel, obj)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				break
+			}
+		}
+		if !ok {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// Manage requires manage on rbac:<object(c)> from the principal set by Auth.
+func (a *Authorizer) Manage(object func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFrom(c)
+		if !ok || principal.Subject == "" {
+			unauthenticated(c, ErrNoCredentials)
+			return
+		}
+		denied, err := a.Authorize(c.Request.Context(), principal, object(c))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError,
+				domain.ErrorResponse{Code: "internal", Message: err.Error()})
+			return
+		}
+		if denied != "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, domain.ErrorResponse{
+				Code: "permission_denied",
+				Message: principal.Subject + " has no " + AuthzRel + " on " +
+					AuthzNs + ":" + denied,
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// RevisionHeader carries the revision a request must read at least, and
+// the one its writes reached in the response.
+const RevisionHeader = "X-Revision"
+
+// Revision makes the reads of the request at least as fresh as the revision
+// of its X-Revision header and answers the revision the request reached,
+// which the writes, answering without a body, always do.
+func Revision() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rev := domain.NewRevision(c.GetHeader(RevisionHeader))
+		defer rev.End()
+		c.Request = c.Request.WithContext(
+			domain.WithRevision(c.Request.Context(), rev))
+		c.Next()
+		if reached := rev.Reached(); reached != "" && !c.Writer.Written() {
+			c.Header(RevisionHeader, reached)
+		}
+	}
+}
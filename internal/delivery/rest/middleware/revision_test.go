@@ -0,0 +1,40 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevision(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Revision())
+	var atLeast string
+	r.POST("/", func(c *gin.Context) {
+		rev := domain.RevisionFrom(c.Request.Context())
+		atLeast = rev.AtLeast
+		rev.Reach("8")
+		c.Status(http.StatusCreated)
+	})
+	r.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(middleware.RevisionHeader, "7")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "7", atLeast)
+	assert.Equal(t, "8", w.Header().Get(middleware.RevisionHeader))
+
+	// nothing reached, nothing answered
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, w.Header().Get(middleware.RevisionHeader))
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+const TenantHeader = "X-Tenant"
+
+var tenantName = regexp.MustCompile(`^[A-Za-z0-9_.\-]{1,64}$`)
+
+var (
+	ErrInvalidTenant = errors.New(
+		"tenant: only up to 64 letters, digits and _.- are allowed")
+	ErrTenantOutOfReach = errors.New("tenant is out of reach")
+)
+
+// BindTenant returns the tenant a request for tenant reaches: the one of a
+// principal bound to a tenant when none is requested. It fails with
+// ErrInvalidTenant for a malformed name and with ErrTenantOutOfReach for a
+// tenant other than the one of the principal.
+func BindTenant(tenant string, principal Principal) (string, error) {
+	if tenant != "" && !tenantName.MatchString(tenant) {
+		return "", ErrInvalidTenant
+	}
+	if principal.Tenant == "" {
+		return tenant, nil
+	}
+	if tenant != "" && tenant != principal.Tenant {
+		return "", errors.Wrap(ErrTenantOutOfReach, "tenant "+tenant)
+	}
+	return principal.Tenant, nil
+}
+
+// Tenant scopes the request context to the tenant of the :tenant path
+// parameter or of the X-Tenant header, the default tenant when none is
+// given. A principal bound to a tenant is kept inside it.
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenant, header := c.Param("tenant"), c.GetHeader(TenantHeader)
+		if tenant == "" {
+			tenant = header
+		} else if header != "" && header != tenant {
+			invalidTenant(c, "tenant: path and "+TenantHeader+" header differ")
+			return
+		}
+
+		principal, _ := PrincipalFrom(c)
+		tenant, err := BindTenant(tenant, principal)
+		switch {
+		case errors.Is(err, ErrTenantOutOfReach):
+			c.AbortWithStatusJSON(http.StatusForbidden, domain.ErrorResponse{
+				Code:    "permission_denied",
+				Message: err.Error(),
+			})
+			return
+		case err != nil:
+			invalidTenant(c, err.Error())
+			return
+		}
+		c.Request = c.Request.WithContext(
+			domain.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
+
+func invalidTenant(c *gin.Context, msg string) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, domain.ErrorResponse{
+		Code:    "invalid_argument",
+		Message: msg,
+	})
+}
@@ -0,0 +1,64 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenant(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Auth(middleware.NewAPIKeyAuthenticator([]middleware.APIKey{
+		{Key: "operator-key", Subject: "operator",
+			Scopes: []middleware.Scope{middleware.AdminScope}},
+		{Key: "acme-key", Subject: "acme-ci", Tenant: "acme",
+			Scopes: []middleware.Scope{middleware.AdminScope}},
+	})))
+	r.Use(middleware.Tenant())
+	tenant := func(c *gin.Context) {
+		c.String(http.StatusOK, domain.TenantFrom(c.Request.Context()))
+	}
+	r.GET("/", tenant)
+	r.GET("/tenants/:tenant", tenant)
+
+	tests := []struct {
+		name   string
+		key    string
+		path   string
+		header string
+		status int
+		tenant string
+	}{
+		{"default", "operator-key", "/", "", http.StatusOK, ""},
+		{"header", "operator-key", "/", "globex", http.StatusOK, "globex"},
+		{"path", "operator-key", "/tenants/globex", "", http.StatusOK,
+			"globex"},
+		{"path and header differ", "operator-key", "/tenants/globex", "acme",
+			http.StatusBadRequest, ""},
+		{"invalid", "operator-key", "/", "acme corp", http.StatusBadRequest,
+			""},
+		{"bound principal", "acme-key", "/", "", http.StatusOK, "acme"},
+		{"bound principal elsewhere", "acme-key", "/tenants/globex", "",
+			http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-API-Key", tt.key)
+			if tt.header != "" {
+				req.Header.Set(middleware.TenantHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.tenant, w.Body.String())
+			}
+		})
+	}
+}
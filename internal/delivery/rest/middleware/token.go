@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	errors "github.com/rotisserie/eris"
+)
+
+// TokenOptions are the claims a token must carry besides a subject and an
+// expiry, empty ones are not checked.
+type TokenOptions struct {
+	Issuer   string
+	Audience string
+}
+
+// TokenAuthenticator accepts the JWTs sent as "Authorization: Bearer" whose
+// signature algorithm is one of algs. The scope claim is a space separated
+// list of scopes, the tenant claim binds the caller to a tenant.
+type TokenAuthenticator struct {
+	algs []jose.SignatureAlgorithm
+	// key returns the key verifying the token signed by the key id kid
+	key  func(kid string) (any, error)
+	opts TokenOptions
+	now  func() time.Time
+}
+
+// signatureAlgs are the algorithms of every authenticator, so that a token
+// meant for another one parses and is left to it.
+var signatureAlgs = []jose.SignatureAlgorithm{
+	jose.HS256, jose.HS384, jose.HS512,
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.ES256, jose.ES384, jose.ES512,
+}
+
+type tokenClaims struct {
+	jwt.Claims
+	Scope  string `json:"scope"`
+	Tenant string `json:"tenant"`
+}
+
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	scheme, raw, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return Principal{}, ErrNoCredentials
+	}
+	token, err := jwt.ParseSigned(raw, signatureAlgs)
+	if err != nil {
+		return Principal{}, errors.Wrap(err, "malformed token")
+	}
+	header := token.Headers[0]
+	if !slices.Contains(a.algs, jose.SignatureAlgorithm(header.Algorithm)) {
+		return Principal{}, ErrNoCredentials
+	}
+	key, err := a.key(header.KeyID)
+	if err != nil {
+		return Principal{}, err
+	}
+	var claims tokenClaims
+	if err := token.Claims(key, &claims); err != nil {
+		return Principal{}, errors.Wrap(err, "invalid token")
+	}
+
+	expected := jwt.Expected{Issuer: a.opts.Issuer, Time: a.now()}
+	if a.opts.Audience != "" {
+		expected.AnyAudience = jwt.Audience{a.opts.Audience}
+	}
+	switch {
+	case claims.Subject == "":
+		return Principal{}, errors.New("token without sub")
+	case claims.Expiry == nil:
+		return Principal{}, errors.New("token without exp")
+	}
+	if err := claims.ValidateWithLeeway(expected, 0); err != nil {
+		return Principal{}, errors.Wrap(err, "invalid token")
+	}
+
+	principal := Principal{Subject: claims.Subject, Tenant: claims.Tenant}
+	for _, s := range strings.Fields(claims.Scope) {
+		if ValidScope(Scope(s)) {
+			principal.Scopes = append(principal.Scopes, Scope(s))
+		}
+	}
+	return principal, nil
+}
+
+// NewHMACAuthenticator accepts the tokens signed with HS256, HS384 or HS512
+// and the shared secret.
+func NewHMACAuthenticator(secret []byte, opts TokenOptions) *TokenAuthenticator {
+	return &TokenAuthenticator{
+		algs: []jose.SignatureAlgorithm{jose.HS256, jose.HS384, jose.HS512},
+		key:  func(string) (any, error) { return secret, nil },
+		opts: opts,
+		now:  time.Now,
+	}
+}
+
+// NewJWKSAuthenticator accepts the tokens signed with RS256, RS384, RS512,
+// ES256, ES384 or ES512 by one of the keys of the local JWKS file.
+func NewJWKSAuthenticator(file string, opts TokenOptions) (
+	*TokenAuthenticator, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "read jwks")
+	}
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, errors.Wrap(err, "parse jwks")
+	}
+	keys := map[string]any{}
+	for i, k := range set.Keys {
+		// only the public half verifies, whatever the file holds
+		public := k.Public()
+		if !public.Valid() {
+			return nil, errors.Errorf("jwks keys[%d]: no public key", i)
+		}
+		keys[k.KeyID] = public.Key
+	}
+	return &TokenAuthenticator{
+		algs: []jose.SignatureAlgorithm{
+			jose.RS256, jose.RS384, jose.RS512,
+			jose.ES256, jose.ES384, jose.ES512,
+		},
+		key: func(kid string) (any, error) {
+			key, ok := keys[kid]
+			if !ok {
+				return nil, errors.New("unknown token key " + kid)
+			}
+			return key, nil
+		},
+		opts: opts,
+		now:  time.Now,
+	}, nil
+}
@@ -1,9 +1,13 @@
 package rest
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	errors "github.com/rotisserie/eris"
 	"github.com/skyrocketOoO/RBAC-server/domain"
 )
 
@@ -33,7 +37,7 @@ func (d *RestDelivery) Ping(c *gin.Context) {
 func (d *RestDelivery) Healthy(c *gin.Context) {
 	// do something check
 	if err := d.usecase.Healthy(c.Request.Context()); err != nil {
-		c.JSON(http.StatusServiceUnavailable, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 
@@ -43,15 +47,16 @@ func (d *RestDelivery) Healthy(c *gin.Context) {
 func (d *RestDelivery) DeleteUser(c *gin.Context) {
 	err := d.usecase.DeleteUser(c.Request.Context(), c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
 }
 
 func (d *RestDelivery) UserGetPermissions(c *gin.Context) {
 	pers, err := d.usecase.UserGetPermissions(c.Request.Context(), c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, pers)
@@ -60,17 +65,17 @@ func (d *RestDelivery) UserGetPermissions(c *gin.Context) {
 func (d *RestDelivery) UserGetRoles(c *gin.Context) {
 	roles, err := d.usecase.UserGetRoles(c.Request.Context(), c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, roles)
 }
 
 func (d *RestDelivery) UserCheck(c *gin.Context) {
-	ok, err := d.usecase.UserCheck(c, c.Param("name"), c.Param("objns"),
+	ok, err := d.usecase.UserCheck(c.Request.Context(), c.Param("name"), c.Param("objns"),
 		c.Param("rel"), c.Param("objname"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	if !ok {
@@ -79,14 +84,51 @@ func (d *RestDelivery) UserCheck(c *gin.Context) {
 	}
 }
 
-func (d *RestDelivery) UserAddPermission(c *gin.Context) {
+func (d *RestDelivery) UserExplain(c *gin.Context) {
+	maxDepth := math.MaxInt
+	if depth := c.Query("max_depth"); depth != "" {
+		var err error
+		if maxDepth, err = strconv.Atoi(depth); err != nil || maxDepth <= 0 {
+			d.fail(c, errors.Wrap(domain.ErrBodyAttribute,
+				"max_depth: must be a positive integer"))
+			return
+		}
+	}
+	explanation, err := d.usecase.UserExplain(c.Request.Context(), c.Param("name"),
+		c.Param("objns"), c.Param("rel"), c.Param("objname"), maxDepth)
+	if err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, explanation)
+}
+
+func (d *RestDelivery) BulkCheck(c *gin.Context) {
 	var requestBody struct {
-		Relation string `json:"relation"`
-		ObjNs    string `json:"obj_ns"`
-		ObjName  string `json:"obj_name"`
+		Checks []domain.CheckRequest `json:"checks"`
+	}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		d.fail(c, bindError(err))
+		return
 	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+	results, err := d.usecase.BulkCheck(c.Request.Context(), requestBody.Checks)
+	if err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+func (d *RestDelivery) UserAddPermission(c *gin.Context) {
+	var requestBody struct {
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
+		domain.Period
+	}
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.UserAddPermission(c.Request.Context(), c.Param("name"),
@@ -94,19 +136,22 @@ func (d *RestDelivery) UserAddPermission(c *gin.Context) {
 			Rel:  requestBody.Relation,
 			Ns:   requestBody.ObjNs,
 			Name: requestBody.ObjName,
-		}); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		}, requestBody.Period, c.Query("if_not_exists") == "true"); err != nil {
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusCreated)
 }
+
 func (d *RestDelivery) UserRemovePermission(c *gin.Context) {
 	var requestBody struct {
-		Relation string `json:"relation"`
-		ObjNs    string `json:"obj_ns"`
-		ObjName  string `json:"obj_name"`
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
 	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.UserRemovePermission(c.Request.Context(), c.Param("name"),
@@ -115,52 +160,60 @@ func (d *RestDelivery) UserRemovePermission(c *gin.Context) {
 			Ns:   requestBody.ObjNs,
 			Name: requestBody.ObjName,
 		}); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
 }
 
 func (d *RestDelivery) UserAddRole(c *gin.Context) {
 	var requestBody struct {
-		RoleName string `json:"role_name"`
+		RoleName string `json:"role_name" binding:"required,max=256,identifier"`
+		domain.Period
 	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.UserAddRole(c.Request.Context(), c.Param("name"),
-		requestBody.RoleName); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		requestBody.RoleName, requestBody.Period,
+		c.Query("if_not_exists") == "true"); err != nil {
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusCreated)
 }
 
 func (d *RestDelivery) UserRemoveRole(c *gin.Context) {
 	var requestBody struct {
-		RoleName string `json:"role_name"`
+		RoleName string `json:"role_name" binding:"required,max=256,identifier"`
 	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.UserRemoveRole(c.Request.Context(), c.Param("name"),
 		requestBody.RoleName); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
 }
 
 func (d *RestDelivery) DeleteRole(c *gin.Context) {
 	if err := d.usecase.DeleteRole(c.Request.Context(), c.Param("name")); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
 }
 
 func (d *RestDelivery) RoleGetUsers(c *gin.Context) {
 	users, err := d.usecase.RoleGetUsers(c.Request.Context(), c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, users)
@@ -169,7 +222,7 @@ func (d *RestDelivery) RoleGetUsers(c *gin.Context) {
 func (d *RestDelivery) RoleGetPermissions(c *gin.Context) {
 	pers, err := d.usecase.RoleGetPermissions(c.Request.Context(), c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, pers)
@@ -177,12 +230,14 @@ func (d *RestDelivery) RoleGetPermissions(c *gin.Context) {
 
 func (d *RestDelivery) RoleAddPermission(c *gin.Context) {
 	var requestBody struct {
-		Relation string `json:"relation"`
-		ObjNs    string `json:"obj_ns"`
-		ObjName  string `json:"obj_name"`
-	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
+		domain.Period
+	}
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.RoleAddPermission(c.Request.Context(), c.Param("name"),
@@ -190,20 +245,22 @@ func (d *RestDelivery) RoleAddPermission(c *gin.Context) {
 			Rel:  requestBody.Relation,
 			Ns:   requestBody.ObjNs,
 			Name: requestBody.ObjName,
-		}); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		}, requestBody.Period, c.Query("if_not_exists") == "true"); err != nil {
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusCreated)
 }
 
 func (d *RestDelivery) RoleRemovePermission(c *gin.Context) {
 	var requestBody struct {
-		Relation string `json:"relation"`
-		ObjNs    string `json:"obj_ns"`
-		ObjName  string `json:"obj_name"`
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
 	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.RoleRemovePermission(c.Request.Context(), c.Param("name"),
@@ -212,45 +269,144 @@ func (d *RestDelivery) RoleRemovePermission(c *gin.Context) {
 			Ns:   requestBody.ObjNs,
 			Name: requestBody.ObjName,
 		}); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
+}
+
+func (d *RestDelivery) UserAddDeny(c *gin.Context) {
+	var requestBody struct {
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
+		domain.Period
+	}
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
+		return
+	}
+	if err := d.usecase.UserAddDeny(c.Request.Context(), c.Param("name"),
+		domain.Permission{
+			Rel:  requestBody.Relation,
+			Ns:   requestBody.ObjNs,
+			Name: requestBody.ObjName,
+		}, requestBody.Period, c.Query("if_not_exists") == "true"); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusCreated)
+}
+
+func (d *RestDelivery) UserRemoveDeny(c *gin.Context) {
+	var requestBody struct {
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
+	}
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
+		return
+	}
+	if err := d.usecase.UserRemoveDeny(c.Request.Context(), c.Param("name"),
+		domain.Permission{
+			Rel:  requestBody.Relation,
+			Ns:   requestBody.ObjNs,
+			Name: requestBody.ObjName,
+		}); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (d *RestDelivery) RoleAddDeny(c *gin.Context) {
+	var requestBody struct {
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
+		domain.Period
+	}
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
+		return
+	}
+	if err := d.usecase.RoleAddDeny(c.Request.Context(), c.Param("name"),
+		domain.Permission{
+			Rel:  requestBody.Relation,
+			Ns:   requestBody.ObjNs,
+			Name: requestBody.ObjName,
+		}, requestBody.Period, c.Query("if_not_exists") == "true"); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusCreated)
+}
+
+func (d *RestDelivery) RoleRemoveDeny(c *gin.Context) {
+	var requestBody struct {
+		Relation string `json:"relation" binding:"required,max=256,identifier"`
+		ObjNs    string `json:"obj_ns" binding:"required,max=256,namespace"`
+		ObjName  string `json:"obj_name" binding:"required,max=256,identifier"`
+	}
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
+		return
+	}
+	if err := d.usecase.RoleRemoveDeny(c.Request.Context(), c.Param("name"),
+		domain.Permission{
+			Rel:  requestBody.Relation,
+			Ns:   requestBody.ObjNs,
+			Name: requestBody.ObjName,
+		}); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
 }
 
 func (d *RestDelivery) RoleInheritRole(c *gin.Context) {
 	var requestBody struct {
-		Name string `json:"name"`
+		Name string `json:"name" binding:"required,max=256,identifier"`
 	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.RoleInheritRole(c.Request.Context(), c.Param("name"),
-		requestBody.Name); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		requestBody.Name, c.Query("if_not_exists") == "true"); err != nil {
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusCreated)
 }
 
 func (d *RestDelivery) RoleUnInheritRole(c *gin.Context) {
 	var requestBody struct {
-		Name string `json:"name"`
+		Name string `json:"name" binding:"required,max=256,identifier"`
 	}
-	if err := c.ShouldBindUri(&requestBody); err != nil {
-		c.JSON(400, gin.H{"msg": err})
+	// the body may have been read already to authorize the request
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
+		d.fail(c, bindError(err))
 		return
 	}
 	if err := d.usecase.RoleUnInheritRole(c.Request.Context(), c.Param("name"),
 		requestBody.Name); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
 }
 
 func (d *RestDelivery) RoleGetChildRole(c *gin.Context) {
 	roles, err := d.usecase.RoleGetChildRole(c.Request.Context(), c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, roles)
@@ -259,7 +415,7 @@ func (d *RestDelivery) RoleGetChildRole(c *gin.Context) {
 func (d *RestDelivery) RoleGetParentRole(c *gin.Context) {
 	roles, err := d.usecase.RoleGetParentRole(c.Request.Context(), c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, roles)
@@ -268,16 +424,17 @@ func (d *RestDelivery) RoleGetParentRole(c *gin.Context) {
 func (d *RestDelivery) DeleteObject(c *gin.Context) {
 	if err := d.usecase.DeleteObject(c.Request.Context(), c.Param("ns"),
 		c.Param("name")); err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
 }
 
 func (d *RestDelivery) WhichRoleHasPermission(c *gin.Context) {
 	roles, err := d.usecase.WhichRoleHasPermission(c.Request.Context(), c.Param("ns"),
 		c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, roles)
@@ -287,8 +444,93 @@ func (d *RestDelivery) WhichUserHasPermission(c *gin.Context) {
 	users, err := d.usecase.WhichUserHasPermission(c.Request.Context(), c.Param("ns"),
 		c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.Response{Msg: err.Error()})
+		d.fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, users)
 }
+
+func (d *RestDelivery) FindCycles(c *gin.Context) {
+	cycles, err := d.usecase.FindCycles(c.Request.Context())
+	if err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, cycles)
+}
+
+func (d *RestDelivery) CacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, d.usecase.CacheStats(c.Request.Context()))
+}
+
+func (d *RestDelivery) RebuildClosure(c *gin.Context) {
+	if err := d.usecase.RebuildClosure(c.Request.Context()); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (d *RestDelivery) VerifyClosure(c *gin.Context) {
+	report, err := d.usecase.VerifyClosure(c.Request.Context())
+	if err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (d *RestDelivery) ApplyOperations(c *gin.Context) {
+	var requestBody struct {
+		Operations []domain.Operation `json:"operations"`
+	}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		d.fail(c, bindError(err))
+		return
+	}
+	if err := d.usecase.ApplyOperations(c.Request.Context(),
+		requestBody.Operations); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (d *RestDelivery) GetSchema(c *gin.Context) {
+	schema, err := d.usecase.GetSchema(c.Request.Context())
+	if err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+func (d *RestDelivery) SetSchema(c *gin.Context) {
+	var schema domain.Schema
+	if err := c.ShouldBindJSON(&schema); err != nil {
+		d.fail(c, bindError(err))
+		return
+	}
+	if err := d.usecase.SetSchema(c.Request.Context(), schema); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (d *RestDelivery) ExportTenant(c *gin.Context) {
+	edges, err := d.usecase.ExportTenant(c.Request.Context())
+	if err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, edges)
+}
+
+func (d *RestDelivery) PurgeTenant(c *gin.Context) {
+	if err := d.usecase.PurgeTenant(c.Request.Context()); err != nil {
+		d.fail(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,454 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skyrocketOoO/RBAC-server/api"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/watch"
+	"github.com/skyrocketOoO/RBAC-server/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeUsecase records the write calls it gets and answers them with err,
+// checks are answered from grants.
+type fakeUsecase struct {
+	domain.Usecase
+	calls   []string
+	err     error
+	grants  []string
+	changes *watch.Broadcaster
+}
+
+func (f *fakeUsecase) Watch(c context.Context, token string,
+	send func(domain.Change) error) error {
+	return f.changes.Watch(c, token, send)
+}
+
+func (f *fakeUsecase) UserCheck(c context.Context, username string,
+	objNs string, relation string, objName string) (bool, error) {
+	return slices.Contains(f.grants, username+" "+relation+" "+objNs+":"+
+		objName), nil
+}
+
+func (f *fakeUsecase) record(format string, a ...any) error {
+	f.calls = append(f.calls, fmt.Sprintf(format, a...))
+	return f.err
+}
+
+func (f *fakeUsecase) UserAddPermission(c context.Context, username string,
+	permission domain.Permission, period domain.Period, ifNotExists bool) error {
+	return f.record("UserAddPermission %s %v %v", username, permission,
+		ifNotExists)
+}
+
+func (f *fakeUsecase) UserRemovePermission(c context.Context, username string,
+	permission domain.Permission) error {
+	return f.record("UserRemovePermission %s %v", username, permission)
+}
+
+func (f *fakeUsecase) UserAddRole(c context.Context, username string,
+	roleName string, period domain.Period, ifNotExists bool) error {
+	return f.record("UserAddRole %s %s %v", username, roleName, ifNotExists)
+}
+
+func (f *fakeUsecase) UserRemoveRole(c context.Context, username string,
+	roleName string) error {
+	return f.record("UserRemoveRole %s %s", username, roleName)
+}
+
+func (f *fakeUsecase) RoleAddPermission(c context.Context, roleName string,
+	permission domain.Permission, period domain.Period, ifNotExists bool) error {
+	return f.record("RoleAddPermission %s %v %v", roleName, permission,
+		ifNotExists)
+}
+
+func (f *fakeUsecase) RoleRemovePermission(c context.Context, roleName string,
+	permission domain.Permission) error {
+	return f.record("RoleRemovePermission %s %v", roleName, permission)
+}
+
+func (f *fakeUsecase) RoleInheritRole(c context.Context, parentName string,
+	childName string, ifNotExists bool) error {
+	return f.record("RoleInheritRole %s %s %v", parentName, childName,
+		ifNotExists)
+}
+
+func (f *fakeUsecase) RoleUnInheritRole(c context.Context, parentName string,
+	childName string) error {
+	return f.record("RoleUnInheritRole %s %s", parentName, childName)
+}
+
+func TestWriteEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	permission := `{"relation": "read", "obj_ns": "doc", "obj_name": "readme"}`
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		err      error
+		status   int
+		code     string
+		call     string
+		detailed string
+	}{
+		{
+			name: "add user permission", method: http.MethodPost,
+			path: "/user/alice/permission", body: permission,
+			status: http.StatusCreated,
+			call:   "UserAddPermission alice {read doc readme} false",
+		},
+		{
+			name: "add user permission if not exists", method: http.MethodPost,
+			path: "/user/alice/permission?if_not_exists=true", body: permission,
+			status: http.StatusCreated,
+			call:   "UserAddPermission alice {read doc readme} true",
+		},
+		{
+			name: "remove user permission", method: http.MethodDelete,
+			path: "/user/alice/permission", body: permission,
+			status: http.StatusNoContent,
+			call:   "UserRemovePermission alice {read doc readme}",
+		},
+		{
+			name: "add user role", method: http.MethodPost,
+			path: "/user/alice/role", body: `{"role_name": "admin"}`,
+			status: http.StatusCreated, call: "UserAddRole alice admin false",
+		},
+		{
+			name: "remove user role", method: http.MethodDelete,
+			path: "/user/alice/role", body: `{"role_name": "admin"}`,
+			status: http.StatusNoContent, call: "UserRemoveRole alice admin",
+		},
+		{
+			name: "add role permission", method: http.MethodPost,
+			path: "/role/admin/permission", body: permission,
+			status: http.StatusCreated,
+			call:   "RoleAddPermission admin {read doc readme} false",
+		},
+		{
+			name: "remove role permission", method: http.MethodDelete,
+			path: "/role/admin/permission", body: permission,
+			status: http.StatusNoContent,
+			call:   "RoleRemovePermission admin {read doc readme}",
+		},
+		{
+			name: "inherit role", method: http.MethodPost,
+			path: "/role/admin/inherit", body: `{"name": "ops"}`,
+			status: http.StatusCreated, call: "RoleInheritRole admin ops false",
+		},
+		{
+			name: "uninherit role", method: http.MethodDelete,
+			path: "/role/admin/inherit", body: `{"name": "ops"}`,
+			status: http.StatusNoContent, call: "RoleUnInheritRole admin ops",
+		},
+		{
+			name: "empty body", method: http.MethodPost,
+			path: "/user/alice/permission", body: ``,
+			status: http.StatusBadRequest, code: "invalid_argument",
+		},
+		{
+			name: "malformed body", method: http.MethodPost,
+			path: "/user/alice/role", body: `{"role_name": `,
+			status: http.StatusBadRequest, code: "invalid_argument",
+		},
+		{
+			name: "missing field", method: http.MethodPost,
+			path:   "/user/alice/permission",
+			body:   `{"relation": "read", "obj_ns": "doc"}`,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "obj_name: missing",
+		},
+		{
+			name: "empty field", method: http.MethodDelete,
+			path: "/user/alice/role", body: `{"role_name": ""}`,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "role_name: missing",
+		},
+		{
+			name: "disallowed characters", method: http.MethodPost,
+			path:   "/role/admin/permission",
+			body:   `{"relation": "!read", "obj_ns": "doc", "obj_name": "readme"}`,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "relation: only letters, digits and _.@+-/ are allowed",
+		},
+		{
+			name: "too long", method: http.MethodPost,
+			path:   "/role/admin/inherit",
+			body:   `{"name": "` + strings.Repeat("a", 257) + `"}`,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "name: longer than 256 characters",
+		},
+		{
+			name: "disallowed characters in path", method: http.MethodPost,
+			path: "/user/al!ce/permission", body: permission,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "name: only letters, digits and _.@+-/ are allowed",
+		},
+		{
+			name: "separator in path", method: http.MethodDelete,
+			path: "/object/doc:x/readme", body: ``,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "ns: only letters, digits and _.@+- are allowed",
+		},
+		{
+			name: "slash in namespace", method: http.MethodPost,
+			path:   "/user/alice/permission",
+			body:   `{"relation": "read", "obj_ns": "doc/a", "obj_name": "b"}`,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "obj_ns: only letters, digits and _.@+- are allowed",
+		},
+		{
+			name: "too long path", method: http.MethodPost,
+			path:   "/role/" + strings.Repeat("a", 257) + "/inherit",
+			body:   `{"name": "ops"}`,
+			status: http.StatusBadRequest, code: "invalid_argument",
+			detailed: "name: longer than 256 characters",
+		},
+		{
+			name: "duplicate", method: http.MethodPost,
+			path: "/user/alice/role", body: `{"role_name": "admin"}`,
+			err:    domain.ErrDuplicateRecord,
+			status: http.StatusConflict, code: "already_exists",
+			call: "UserAddRole alice admin false",
+		},
+		{
+			name: "cycle", method: http.MethodPost,
+			path: "/role/admin/inherit", body: `{"name": "ops"}`,
+			err:    domain.ErrGraphCycle,
+			status: http.StatusConflict, code: "graph_cycle",
+			call: "RoleInheritRole admin ops false",
+		},
+		{
+			name: "not found", method: http.MethodDelete,
+			path: "/user/alice/role", body: `{"role_name": "admin"}`,
+			err:    domain.ErrRecordNotFound,
+			status: http.StatusNotFound, code: "not_found",
+			call: "UserRemoveRole alice admin",
+		},
+		{
+			name: "internal", method: http.MethodDelete,
+			path: "/role/admin/inherit", body: `{"name": "ops"}`,
+			err:    context.Canceled,
+			status: http.StatusInternalServerError, code: "internal",
+			call: "RoleUnInheritRole admin ops",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := &fakeUsecase{err: tt.err}
+			r := gin.New()
+			r.Use(middleware.Auth())
+			api.Binding(r, rest.NewDelivery(usecase), nil)
+
+			req := httptest.NewRequest(tt.method, tt.path,
+				strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.call == "" {
+				assert.Empty(t, usecase.calls)
+			} else {
+				assert.Equal(t, []string{tt.call}, usecase.calls)
+			}
+			if tt.code == "" {
+				assert.Empty(t, w.Body.String())
+				return
+			}
+			var res domain.ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.code, res.Code)
+			if tt.detailed != "" {
+				assert.Contains(t, res.Details, tt.detailed)
+			}
+		})
+	}
+}
+
+func TestInheritCycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := memory.NewMemoryRepository()
+	schemaInfra := schema.NewSchemaInfra()
+	r := gin.New()
+	r.Use(middleware.Auth())
+	api.Binding(r, rest.NewDelivery(usecase.NewUsecase(nil,
+		graph.NewGraphInfra(repo, schemaInfra), repo, schemaInfra)), nil)
+	inherit := func(parent string, child string) (int, domain.ErrorResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/role/"+parent+"/inherit",
+			strings.NewReader(`{"name": "`+child+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var res domain.ErrorResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		return w.Code, res
+	}
+
+	status, _ := inherit("admin", "editor")
+	assert.Equal(t, http.StatusCreated, status)
+	status, _ = inherit("editor", "viewer")
+	assert.Equal(t, http.StatusCreated, status)
+	for _, tt := range [][2]string{
+		{"editor", "admin"}, {"viewer", "admin"}, {"admin", "admin"},
+	} {
+		status, res := inherit(tt[0], tt[1])
+		assert.Equal(t, http.StatusConflict, status, tt)
+		assert.Equal(t, "graph_cycle", res.Code, tt)
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	usecase := &fakeUsecase{grants: []string{
+		"alice manage rbac:role/admin",
+		"alice manage rbac:role/staff",
+		"alice manage rbac:object/doc/readme",
+		"bob manage rbac:user/carol",
+		"bob manage rbac:object/doc/readme",
+		"bob manage rbac:object/doc/a/b",
+		"bob manage rbac:object/rbac/system",
+		"root manage rbac:system",
+	}}
+	r := gin.New()
+	keys := []middleware.APIKey{}
+	for _, name := range []string{"alice", "bob", "root", "boot"} {
+		keys = append(keys, middleware.APIKey{Key: name + "-key", Subject: name,
+			Scopes: []middleware.Scope{middleware.AdminScope}})
+	}
+	r.Use(middleware.Auth(middleware.NewAPIKeyAuthenticator(keys)))
+	api.Binding(r, rest.NewDelivery(usecase),
+		middleware.NewAuthorizer(usecase, []string{"boot"}))
+
+	permission := `{"relation": "read", "obj_ns": "doc", "obj_name": "readme"}`
+	secret := `{"relation": "read", "obj_ns": "doc", "obj_name": "secret"}`
+	system := `{"relation": "manage", "obj_ns": "rbac", "obj_name": "system"}`
+	// doc:a/b and doc/a:b would share rbac:object/doc/a/b unescaped
+	nested := `{"relation": "read", "obj_ns": "doc", "obj_name": "a/b"}`
+	colliding := `{"relation": "read", "obj_ns": "doc/a", "obj_name": "b"}`
+	assert.NotEqual(t, middleware.TargetObject("doc", "a/b"),
+		middleware.TargetObject("doc/a", "b"))
+	tests := []struct {
+		name   string
+		caller string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"role manager changes the role", "alice", http.MethodPost,
+			"/role/admin/permission", permission, http.StatusCreated},
+		{"role manager changes another role", "alice", http.MethodPost,
+			"/role/ops/permission", permission, http.StatusForbidden},
+		{"role manager adds a member", "alice", http.MethodPost,
+			"/user/dave/role", `{"role_name": "admin"}`, http.StatusCreated},
+		{"user manager adds the user to a role", "bob", http.MethodPost,
+			"/user/carol/role", `{"role_name": "admin"}`, http.StatusForbidden},
+		{"user manager changes the user", "bob", http.MethodDelete,
+			"/user/carol/permission", permission, http.StatusNoContent},
+		{"user manager grants an unmanaged object", "bob", http.MethodPost,
+			"/user/carol/permission", secret, http.StatusForbidden},
+		{"user manager denies an unmanaged object", "bob", http.MethodPost,
+			"/user/carol/deny", secret, http.StatusForbidden},
+		{"object manager grants a nested name", "bob", http.MethodPost,
+			"/user/carol/permission", nested, http.StatusCreated},
+		{"object manager grants a colliding object", "bob", http.MethodPost,
+			"/user/carol/permission", colliding, http.StatusForbidden},
+		{"role manager grants an unmanaged object", "alice", http.MethodPost,
+			"/role/admin/permission", secret, http.StatusForbidden},
+		{"user manager makes the user a system manager", "bob",
+			http.MethodPost, "/user/carol/permission", system,
+			http.StatusForbidden},
+		{"role manager makes the role a system manager", "alice",
+			http.MethodPost, "/role/admin/permission", system,
+			http.StatusForbidden},
+		{"object manager deletes an rbac object", "bob", http.MethodDelete,
+			"/object/rbac/system", ``, http.StatusForbidden},
+		{"role manager inherits an unmanaged role", "alice", http.MethodPost,
+			"/role/admin/inherit", `{"name": "ops"}`, http.StatusForbidden},
+		{"role manager uninherits an unmanaged role", "alice",
+			http.MethodDelete, "/role/admin/inherit", `{"name": "ops"}`,
+			http.StatusForbidden},
+		{"role manager inherits a managed role", "alice", http.MethodPost,
+			"/role/admin/inherit", `{"name": "staff"}`, http.StatusCreated},
+		{"system manager", "root", http.MethodPost, "/role/ops/inherit",
+			`{"name": "admin"}`, http.StatusCreated},
+		{"system manager grants the system", "root", http.MethodPost,
+			"/user/carol/permission", system, http.StatusCreated},
+		{"super admin", "boot", http.MethodPost, "/role/ops/permission",
+			permission, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path,
+				strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-API-Key", tt.caller+"-key")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestPollWatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	usecase := &fakeUsecase{changes: watch.NewBroadcaster(10)}
+	r := gin.New()
+	r.Use(middleware.Auth())
+	api.Binding(r, rest.NewDelivery(usecase), nil)
+	poll := func(query string) (int, domain.ChangesResponse) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+			"/watch/poll?"+query, nil))
+		var res domain.ChangesResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		return w.Code, res
+	}
+	publish := func(tenant string, name string) {
+		usecase.changes.Publish(domain.Change{Type: domain.CreateChange,
+			Edge: &domain.Edge{Tenant: tenant, UNs: "user", UName: name}})
+	}
+
+	// an idle poll returns the token to resume from once it times out
+	status, res := poll("timeout=10ms")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, res.Changes)
+	assert.Equal(t, "0", res.Token)
+
+	publish("", "alice")
+	publish("acme", "bob")
+	publish("", "carol")
+	status, res = poll("token=0&limit=1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, res.Changes, 1)
+	assert.Equal(t, "alice", res.Changes[0].Edge.UName)
+	status, res = poll("token=" + res.Token)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, res.Changes, 1)
+	assert.Equal(t, "carol", res.Changes[0].Edge.UName)
+	assert.Equal(t, "3", res.Token)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/watch/poll?token=99", nil))
+	assert.Equal(t, http.StatusGone, w.Code)
+	status, _ = poll("timeout=forever")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	v.RegisterValidation("identifier", func(fl validator.FieldLevel) bool {
+		return domain.Identifier.MatchString(fl.Field().String())
+	})
+	v.RegisterValidation("namespace", func(fl validator.FieldLevel) bool {
+		return domain.NamespaceIdentifier.MatchString(fl.Field().String())
+	})
+}
+
+// bindError turns a binding failure into ErrBodyAttribute, wrapped once per
+// offending field so that they all end up in the error details.
+func bindError(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return errors.Wrap(domain.ErrBodyAttribute, err.Error())
+	}
+	err = domain.ErrBodyAttribute
+	for i := len(fieldErrs) - 1; i >= 0; i-- {
+		fe := fieldErrs[i]
+		var msg string
+		switch fe.Tag() {
+		case "required":
+			msg = "missing"
+		case "identifier":
+			msg = domain.IdentifierMsg
+		case "namespace":
+			msg = domain.NamespaceIdentifierMsg
+		case "max":
+			msg = fmt.Sprintf("longer than %s characters", fe.Param())
+		default:
+			msg = "invalid (" + fe.Tag() + ")"
+		}
+		err = errors.Wrap(err, fe.Field()+": "+msg)
+	}
+	return err
+}
+
+// ValidateParams holds the path parameters to the rule of the identifiers of
+// the bodies, before they reach the usecase or the authorization. The tenant
+// is left to middleware.Tenant.
+func (d *RestDelivery) ValidateParams(c *gin.Context) {
+	for _, param := range c.Params {
+		if param.Key == "tenant" {
+			continue
+		}
+		var msg string
+		switch {
+		case len(param.Value) > domain.MaxIdentifier:
+			msg = fmt.Sprintf("longer than %d characters", domain.MaxIdentifier)
+		case (param.Key == "ns" || param.Key == "objns") &&
+			!domain.NamespaceIdentifier.MatchString(param.Value):
+			msg = domain.NamespaceIdentifierMsg
+		case !domain.Identifier.MatchString(param.Value):
+			msg = domain.IdentifierMsg
+		default:
+			continue
+		}
+		d.fail(c, errors.Wrap(domain.ErrBodyAttribute, param.Key+": "+msg))
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+const (
+	defaultPollTimeout = 30 * time.Second
+	maxPollTimeout     = time.Minute
+	defaultPollLimit   = 100
+	maxPollLimit       = 1000
+	// how long a poll waits for the changes following its first one
+	pollLinger = 50 * time.Millisecond
+)
+
+var errPollFull = errors.New("poll full")
+
+// Watch streams the changes as Server-Sent Events, each one carrying its
+// token as id so that a reconnecting client resumes with Last-Event-ID.
+func (d *RestDelivery) Watch(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		token = c.GetHeader("Last-Event-ID")
+	}
+	started := false
+	err := d.usecase.Watch(c.Request.Context(), token,
+		func(change domain.Change) error {
+			if !started {
+				started = true
+				c.Header("Cache-Control", "no-cache")
+				c.Header("X-Accel-Buffering", "no")
+				c.Status(http.StatusOK)
+			}
+			c.Render(-1, sse.Event{
+				Id:    change.Token,
+				Event: string(change.Type),
+				Data:  change,
+			})
+			c.Writer.Flush()
+			return nil
+		})
+	switch {
+	case c.Request.Context().Err() != nil:
+	case !started:
+		d.fail(c, err)
+	default:
+		// the status is sent already, the error is the last event
+		c.Render(-1, sse.Event{Event: "error", Data: domain.ErrorResponse{
+			Code:    "internal",
+			Message: err.Error(),
+		}})
+	}
+}
+
+// PollWatch waits up to timeout for the changes after token and returns at
+// most limit of them together with the token to poll from next.
+func (d *RestDelivery) PollWatch(c *gin.Context) {
+	timeout := defaultPollTimeout
+	if s := c.Query("timeout"); s != "" {
+		var err error
+		timeout, err = time.ParseDuration(s)
+		if err != nil || timeout < 0 || timeout > maxPollTimeout {
+			d.fail(c, errors.Wrapf(domain.ErrBodyAttribute,
+				"timeout: a duration up to %s", maxPollTimeout))
+			return
+		}
+	}
+	limit := defaultPollLimit
+	if s := c.Query("limit"); s != "" {
+		var err error
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > maxPollLimit {
+			d.fail(c, errors.Wrapf(domain.ErrBodyAttribute,
+				"limit: a number from 1 to %d", maxPollLimit))
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	res := domain.ChangesResponse{Changes: []domain.Change{}}
+	err := d.usecase.Watch(ctx, c.Query("token"),
+		func(change domain.Change) error {
+			if change.Type == domain.CheckpointChange {
+				res.Token = change.Token
+				return nil
+			}
+			if len(res.Changes) == 0 {
+				time.AfterFunc(pollLinger, cancel)
+			}
+			res.Changes = append(res.Changes, change)
+			res.Token = change.Token
+			if len(res.Changes) == limit {
+				return errPollFull
+			}
+			return nil
+		})
+	if c.Request.Context().Err() != nil {
+		return
+	}
+	if err != nil && ctx.Err() == nil && !errors.Is(err, errPollFull) {
+		d.fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,275 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// writtenTokens is how many revisions of the writes are remembered to answer
+// the reads which ask for them.
+const writtenTokens = 1024
+
+// Cache keeps the results of the graph traversals, least recently used first
+// out. A result is dropped as soon as an edge from or to one of the vertices
+// the traversal read is written, or when one of the edges it read takes or
+// stops taking effect. It only sees the writes going through Repository, the
+// servers sharing a database each need to see all of them.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	entries map[string]*list.Element
+	// the keys of the entries which read the edges of an anchor
+	readers map[anchor]map[string]struct{}
+	// incremented by every invalidation, an entry computed across one is
+	// not stored
+	epoch uint64
+	// the epoch following the invalidation of each written revision
+	written map[string]uint64
+	tokens  []string
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// anchor is the edges of a tenant leaving (out) or reaching a vertex.
+type anchor struct {
+	tenant string
+	vertex domain.Vertex
+	out    bool
+}
+
+type entry struct {
+	key      string
+	tenant   string
+	value    any
+	anchors  []anchor
+	expires  time.Time
+	epoch    uint64
+	revision string
+}
+
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:    size,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+		readers: map[anchor]map[string]struct{}{},
+		written: map[string]uint64{},
+	}
+}
+
+func (ch *Cache) Stats() domain.CacheStats {
+	ch.mu.Lock()
+	entries := ch.lru.Len()
+	ch.mu.Unlock()
+	return domain.CacheStats{
+		Enabled: true,
+		Entries: entries,
+		Hits:    ch.hits.Load(),
+		Misses:  ch.misses.Load(),
+	}
+}
+
+// get returns the entry of key unless it expired or it may be older than
+// the revision atLeast, which is only known when it was written through this
+// cache.
+func (ch *Cache) get(key string, atLeast string) (value any, revision string,
+	ok bool) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	el, ok := ch.entries[key]
+	if ok {
+		e := el.Value.(*entry)
+		written, known := ch.written[atLeast]
+		switch {
+		case !e.expires.IsZero() && !time.Now().Before(e.expires):
+			ch.remove(el)
+			ok = false
+		case atLeast != "" && (!known || e.epoch < written):
+			ok = false
+		}
+	}
+	if !ok {
+		ch.misses.Add(1)
+		return nil, "", false
+	}
+	ch.hits.Add(1)
+	ch.lru.MoveToFront(el)
+	e := el.Value.(*entry)
+	return e.value, e.revision, true
+}
+
+// footprint collects what a traversal read, from the epoch it started in.
+type footprint struct {
+	mu      sync.Mutex
+	epoch   uint64
+	anchors map[anchor]struct{}
+	expires time.Time
+	// read more than the edges of some vertices, e.g. every edge
+	unbounded bool
+}
+
+func (ch *Cache) begin() *footprint {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	return &footprint{epoch: ch.epoch, anchors: map[anchor]struct{}{}}
+}
+
+// read records that the edges matching filter were read, and when the first
+// of them takes or stops taking effect.
+func (fp *footprint) read(tenant string, filter domain.Edge,
+	edges []domain.Edge) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	bounded := false
+	if filter.UNs != "" && filter.UName != "" {
+		fp.anchors[anchor{tenant: tenant, out: true,
+			vertex: domain.Vertex{Ns: filter.UNs, Name: filter.UName}}] = struct{}{}
+		bounded = true
+	}
+	if filter.VNs != "" && filter.VName != "" {
+		fp.anchors[anchor{tenant: tenant,
+			vertex: domain.Vertex{Ns: filter.VNs, Name: filter.VName}}] = struct{}{}
+		bounded = true
+	}
+	if !bounded {
+		fp.unbounded = true
+	}
+	fp.expireWith(edges)
+}
+
+// readNeighbors records that the edges leaving (out) or reaching the
+// vertices were read.
+func (fp *footprint) readNeighbors(tenant string, vertices []domain.Vertex,
+	out bool, edges []domain.Edge) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	for _, v := range vertices {
+		fp.anchors[anchor{tenant: tenant, vertex: v, out: out}] = struct{}{}
+	}
+	fp.expireWith(edges)
+}
+
+func (fp *footprint) expireWith(edges []domain.Edge) {
+	now := time.Now()
+	for _, edge := range edges {
+		for _, t := range []*time.Time{edge.NotBefore, edge.ExpiresAt} {
+			if t != nil && t.After(now) &&
+				(fp.expires.IsZero() || t.Before(fp.expires)) {
+				fp.expires = *t
+			}
+		}
+	}
+}
+
+// put stores the value computed by the traversal of fp unless a write may
+// have changed what it read meanwhile.
+func (ch *Cache) put(key string, tenant string, value any, fp *footprint,
+	revision string) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	if fp.unbounded {
+		return
+	}
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if ch.epoch != fp.epoch {
+		return
+	}
+
+	if el, ok := ch.entries[key]; ok {
+		ch.remove(el)
+	}
+	e := &entry{
+		key:      key,
+		tenant:   tenant,
+		value:    value,
+		expires:  fp.expires,
+		epoch:    fp.epoch,
+		revision: revision,
+	}
+	for a := range fp.anchors {
+		e.anchors = append(e.anchors, a)
+		if ch.readers[a] == nil {
+			ch.readers[a] = map[string]struct{}{}
+		}
+		ch.readers[a][key] = struct{}{}
+	}
+	ch.entries[key] = ch.lru.PushFront(e)
+	for ch.lru.Len() > ch.size {
+		ch.remove(ch.lru.Back())
+	}
+}
+
+func (ch *Cache) remove(el *list.Element) {
+	e := ch.lru.Remove(el).(*entry)
+	delete(ch.entries, e.key)
+	for _, a := range e.anchors {
+		delete(ch.readers[a], e.key)
+		if len(ch.readers[a]) == 0 {
+			delete(ch.readers, a)
+		}
+	}
+}
+
+// invalidate drops the entries which read the edges of the tenant and
+// returns the epoch following it.
+func (ch *Cache) invalidate(tenant string, edges ...domain.Edge) uint64 {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	ch.epoch++
+	for _, edge := range edges {
+		for _, a := range []anchor{
+			{tenant: tenant, out: true,
+				vertex: domain.Vertex{Ns: edge.UNs, Name: edge.UName}},
+			{tenant: tenant,
+				vertex: domain.Vertex{Ns: edge.VNs, Name: edge.VName}},
+		} {
+			for key := range ch.readers[a] {
+				ch.remove(ch.entries[key])
+			}
+		}
+	}
+	return ch.epoch
+}
+
+// flush drops the entries of the tenant, or every entry when all is set.
+func (ch *Cache) flush(tenant string, all bool) uint64 {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	ch.epoch++
+	for el := ch.lru.Front(); el != nil; {
+		next := el.Next()
+		if all || el.Value.(*entry).tenant == tenant {
+			ch.remove(el)
+		}
+		el = next
+	}
+	return ch.epoch
+}
+
+// wrote remembers that the reads asking for revision must not be answered
+// by the entries computed before epoch.
+func (ch *Cache) wrote(revision string, epoch uint64) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if _, ok := ch.written[revision]; !ok {
+		ch.tokens = append(ch.tokens, revision)
+	}
+	ch.written[revision] = epoch
+	if len(ch.tokens) > writtenTokens {
+		delete(ch.written, ch.tokens[0])
+		ch.tokens = ch.tokens[1:]
+	}
+}
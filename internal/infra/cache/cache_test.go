@@ -0,0 +1,127 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/cache"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	alice  = domain.Vertex{Ns: "user", Name: "alice"}
+	readme = domain.Vertex{Ns: "file", Name: "readme"}
+	member = domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "viewer"}
+	read = domain.Edge{UNs: "role", UName: "viewer", Rel: "read", VNs: "file",
+		VName: "readme"}
+)
+
+func newCached(t *testing.T, edges ...domain.Edge) (*cache.GraphInfra,
+	*cache.Repository) {
+	ch := cache.NewCache(100)
+	repo := ch.Repository(memory.NewMemoryRepository())
+	for _, edge := range edges {
+		assert.NoError(t, repo.Create(context.Background(), edge))
+	}
+	schemaInfra := ch.Schema(schema.NewSchemaInfra())
+	return ch.Graph(graph.NewGraphInfra(repo, schemaInfra)), repo
+}
+
+func check(t *testing.T, c context.Context, g *cache.GraphInfra) bool {
+	ok, err := g.Check(c, alice, readme, "read", domain.SearchCond{})
+	assert.NoError(t, err)
+	return ok
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	c := context.Background()
+	g, repo := newCached(t, member, read)
+
+	assert.True(t, check(t, c, g))
+	assert.True(t, check(t, c, g))
+	assert.Equal(t, domain.CacheStats{Enabled: true, Entries: 1, Hits: 1,
+		Misses: 1}, g.Stats())
+
+	// an edge off the path keeps the entry
+	assert.NoError(t, repo.Create(c, domain.Edge{UNs: "user", UName: "bob",
+		Rel: "member", VNs: "role", VName: "editor"}))
+	assert.True(t, check(t, c, g))
+	assert.Equal(t, uint64(2), g.Stats().Hits)
+
+	// an edge on the path drops it
+	assert.NoError(t, repo.Delete(c, read, false))
+	assert.False(t, check(t, c, g))
+	assert.Equal(t, uint64(2), g.Stats().Misses)
+
+	// and so does an edge the traversal would now reach
+	assert.NoError(t, repo.Create(c, read))
+	assert.True(t, check(t, c, g))
+	assert.Equal(t, uint64(3), g.Stats().Misses)
+
+	// another tenant has its own entries
+	acme := domain.WithTenant(c, "acme")
+	assert.False(t, check(t, acme, g))
+	assert.Equal(t, uint64(4), g.Stats().Misses)
+}
+
+func TestCacheSchemaChange(t *testing.T) {
+	c := context.Background()
+	acme := domain.WithTenant(c, "acme")
+	ch := cache.NewCache(100)
+	repo := ch.Repository(memory.NewMemoryRepository())
+	for _, ctx := range []context.Context{c, acme} {
+		assert.NoError(t, repo.Create(ctx, member))
+		assert.NoError(t, repo.Create(ctx, read))
+	}
+	schemaInfra := ch.Schema(schema.NewSchemaInfra())
+	g := ch.Graph(graph.NewGraphInfra(repo, schemaInfra))
+	assert.True(t, check(t, c, g))
+	assert.True(t, check(t, acme, g))
+
+	// the schema of a tenant only drops its own entries
+	assert.NoError(t, schemaInfra.SetSchema(acme, domain.Schema{}))
+	assert.True(t, check(t, c, g))
+	assert.True(t, check(t, acme, g))
+	assert.Equal(t, domain.CacheStats{Enabled: true, Entries: 2, Hits: 1,
+		Misses: 3}, g.Stats())
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c := context.Background()
+	expires := time.Now().Add(50 * time.Millisecond)
+	expiring := read
+	expiring.ExpiresAt = &expires
+	g, _ := newCached(t, member, expiring)
+
+	assert.True(t, check(t, c, g))
+	assert.True(t, check(t, c, g))
+	time.Sleep(time.Until(expires))
+	assert.False(t, check(t, c, g))
+}
+
+func TestCacheRevision(t *testing.T) {
+	c := context.Background()
+	g, repo := newCached(t, member)
+
+	write := &domain.Revision{}
+	assert.NoError(t, repo.Create(domain.WithRevision(c, write), read))
+	assert.NotEmpty(t, write.Reached())
+	assert.True(t, check(t, c, g))
+
+	// the entry was computed after the write
+	fresh := &domain.Revision{AtLeast: write.Reached()}
+	assert.True(t, check(t, domain.WithRevision(c, fresh), g))
+	assert.Equal(t, uint64(1), g.Stats().Hits)
+	assert.NotEmpty(t, fresh.Reached())
+
+	// a revision written elsewhere is not known to be reflected
+	assert.True(t, check(t, domain.WithRevision(c,
+		&domain.Revision{AtLeast: "12345"}), g))
+	assert.Equal(t, uint64(1), g.Stats().Hits)
+}
@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// GraphInfra answers the checks and searches from the cache, the inner
+// GraphInfra must read its edges from the Repository of the same cache.
+// Explain, GetTree and FindCycles are not cached.
+type GraphInfra struct {
+	domain.GraphInfra
+	cache *Cache
+}
+
+func (ch *Cache) Graph(inner domain.GraphInfra) *GraphInfra {
+	return &GraphInfra{GraphInfra: inner, cache: ch}
+}
+
+func (g *GraphInfra) Stats() domain.CacheStats {
+	return g.cache.Stats()
+}
+
+func (g *GraphInfra) Check(c context.Context, start domain.Vertex,
+	target domain.Vertex, relation string, searchCond domain.SearchCond) (
+	bool, error) {
+	v, err := g.cached(c, checkKey(c, start, target, relation, searchCond),
+		func(c context.Context) (any, error) {
+			return g.GraphInfra.Check(c, start, target, relation, searchCond)
+		})
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// BulkCheck walks the graph for the checks which are not cached only.
+func (g *GraphInfra) BulkCheck(c context.Context, checks []domain.CheckRequest,
+	searchCond domain.SearchCond) ([]bool, error) {
+	results := make([]bool, len(checks))
+	keys := make([]string, len(checks))
+	missed := []int{}
+	rev := domain.RevisionFrom(c)
+	for i, check := range checks {
+		keys[i] = checkKey(c, check.Sbj, check.Obj, check.Rel, searchCond)
+		v, revision, ok := g.cache.get(keys[i], rev.AtLeast)
+		if !ok {
+			missed = append(missed, i)
+			continue
+		}
+		results[i] = v.(bool)
+		if revision != "" {
+			rev.Reach(revision)
+		}
+	}
+	if len(missed) == 0 {
+		return results, nil
+	}
+
+	fp, inner, c := g.begin(c)
+	misses := make([]domain.CheckRequest, len(missed))
+	for j, i := range missed {
+		misses[j] = checks[i]
+	}
+	oks, err := g.GraphInfra.BulkCheck(c, misses, searchCond)
+	g.end(rev, inner)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range missed {
+		results[i] = oks[j]
+		g.cache.put(keys[i], domain.TenantFrom(c), oks[j], fp,
+			inner.Reached())
+	}
+	return results, nil
+}
+
+func (g *GraphInfra) SearchPermissions(c context.Context, start domain.Vertex,
+	isSbj bool, searchCond domain.SearchCond, collectCond domain.CollectCond,
+	maxDepth int) ([]domain.Permission, error) {
+	v, err := g.cached(c, key(c, "permissions", start, isSbj, searchCond,
+		collectCond, maxDepth), func(c context.Context) (any, error) {
+		return g.GraphInfra.SearchPermissions(c, start, isSbj, searchCond,
+			collectCond, maxDepth)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(v.([]domain.Permission)), nil
+}
+
+func (g *GraphInfra) SearchVertices(c context.Context, start domain.Vertex,
+	isSbj bool, searchCond domain.SearchCond, collectCond domain.CollectCond,
+	maxDepth int) ([]domain.Vertex, error) {
+	v, err := g.cached(c, key(c, "vertices", start, isSbj, searchCond,
+		collectCond, maxDepth), func(c context.Context) (any, error) {
+		return g.GraphInfra.SearchVertices(c, start, isSbj, searchCond,
+			collectCond, maxDepth)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(v.([]domain.Vertex)), nil
+}
+
+// cached answers key from the cache, or else computes and stores it. The
+// revision of the context is passed on either way.
+func (g *GraphInfra) cached(c context.Context, key string,
+	compute func(c context.Context) (any, error)) (any, error) {
+	rev := domain.RevisionFrom(c)
+	if v, revision, ok := g.cache.get(key, rev.AtLeast); ok {
+		if revision != "" {
+			rev.Reach(revision)
+		}
+		return v, nil
+	}
+
+	fp, inner, c := g.begin(c)
+	v, err := compute(c)
+	g.end(rev, inner)
+	if err != nil {
+		return nil, err
+	}
+	g.cache.put(key, domain.TenantFrom(c), v, fp, inner.Reached())
+	return v, nil
+}
+
+// begin returns the context of a traversal recording its footprint and the
+// revision it reaches.
+func (g *GraphInfra) begin(c context.Context) (*footprint, *domain.Revision,
+	context.Context) {
+	fp := g.cache.begin()
+	inner := domain.RevisionFrom(c).Derive()
+	c = context.WithValue(c, footprintKey{}, fp)
+	return fp, inner, domain.WithRevision(c, inner)
+}
+
+func (g *GraphInfra) end(rev *domain.Revision, inner *domain.Revision) {
+	if reached := inner.Reached(); reached != "" {
+		rev.Reach(reached)
+	}
+}
+
+func checkKey(c context.Context, sbj domain.Vertex, obj domain.Vertex,
+	relation string, searchCond domain.SearchCond) string {
+	return key(c, "check", sbj, obj, relation, searchCond)
+}
+
+// key identifies a traversal by its arguments in the tenant of c.
+func key(c context.Context, args ...any) string {
+	data, _ := json.Marshal(append([]any{domain.TenantFrom(c)}, args...))
+	return string(data)
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+type footprintKey struct{}
+
+// Repository records what the traversals of GraphInfra read and invalidates
+// the cache on every write.
+type Repository struct {
+	domain.DbRepository
+	cache *Cache
+}
+
+func (ch *Cache) Repository(inner domain.DbRepository) *Repository {
+	return &Repository{DbRepository: inner, cache: ch}
+}
+
+func (r *Repository) Get(c context.Context, filter domain.Edge,
+	queryMode bool) ([]domain.Edge, error) {
+	edges, err := r.DbRepository.Get(c, filter, queryMode)
+	if fp, ok := c.Value(footprintKey{}).(*footprint); ok && err == nil {
+		fp.read(domain.TenantFrom(c), filter, edges)
+	}
+	return edges, err
+}
+
+func (r *Repository) GetNeighbors(c context.Context, vertices []domain.Vertex,
+	isSbj bool) ([]domain.Edge, error) {
+	edges, err := r.DbRepository.GetNeighbors(c, vertices, isSbj)
+	if fp, ok := c.Value(footprintKey{}).(*footprint); ok && err == nil {
+		fp.readNeighbors(domain.TenantFrom(c), vertices, isSbj, edges)
+	}
+	return edges, err
+}
+
+func (r *Repository) Create(c context.Context, edge domain.Edge) error {
+	return r.write(c, func(c context.Context) error {
+		return r.DbRepository.Create(c, edge)
+	}, func() uint64 {
+		return r.cache.invalidate(domain.TenantFrom(c), edge)
+	})
+}
+
+// Delete in query mode flushes the whole tenant, the edges it removed are
+// not known.
+func (r *Repository) Delete(c context.Context, edge domain.Edge,
+	queryMode bool) error {
+	return r.write(c, func(c context.Context) error {
+		return r.DbRepository.Delete(c, edge, queryMode)
+	}, func() uint64 {
+		if queryMode {
+			return r.cache.flush(domain.TenantFrom(c), false)
+		}
+		return r.cache.invalidate(domain.TenantFrom(c), edge)
+	})
+}
+
+func (r *Repository) ApplyOperations(c context.Context,
+	operations []domain.Operation) error {
+	return r.write(c, func(c context.Context) error {
+		return r.DbRepository.ApplyOperations(c, operations)
+	}, func() uint64 {
+		edges := make([]domain.Edge, len(operations))
+		for i, op := range operations {
+			edges[i] = op.Edge
+		}
+		return r.cache.invalidate(domain.TenantFrom(c), edges...)
+	})
+}
+
+// DeleteExpired leaves the cache alone, the entries which read an expired
+// edge expired with it.
+func (r *Repository) DeleteExpired(c context.Context, now time.Time) (
+	int64, error) {
+	return r.DbRepository.DeleteExpired(c, now)
+}
+
+func (r *Repository) ClearAll(c context.Context) error {
+	return r.write(c, r.DbRepository.ClearAll, func() uint64 {
+		return r.cache.flush("", true)
+	})
+}
+
+// write invalidates the cache after the write, whether it failed or not,
+// and remembers the revision it reached.
+func (r *Repository) write(c context.Context,
+	write func(c context.Context) error, invalidate func() uint64) error {
+	rev := domain.RevisionFrom(c)
+	inner := rev.Derive()
+	err := write(domain.WithRevision(c, inner))
+	epoch := invalidate()
+	if reached := inner.Reached(); reached != "" {
+		rev.Reach(reached)
+		r.cache.wrote(reached, epoch)
+	}
+	return err
+}
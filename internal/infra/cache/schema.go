@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// SchemaInfra flushes the entries of a tenant whenever its rewrite rules
+// change.
+type SchemaInfra struct {
+	domain.SchemaInfra
+	cache *Cache
+}
+
+func (ch *Cache) Schema(inner domain.SchemaInfra) *SchemaInfra {
+	return &SchemaInfra{SchemaInfra: inner, cache: ch}
+}
+
+func (s *SchemaInfra) SetSchema(c context.Context,
+	schema domain.Schema) error {
+	err := s.SchemaInfra.SetSchema(c, schema)
+	s.cache.flush(domain.TenantFrom(c), false)
+	return err
+}
@@ -0,0 +1,384 @@
+package closure
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// Closure materializes the roles every user and role of a tenant holds
+// through the member and parent edges, so that checking a user looks its
+// roles up instead of walking the hierarchy. The index of a tenant is built
+// on first use, then kept up to date by the writes going through Repository;
+// like the cache it does not see the writes of other servers.
+type Closure struct {
+	// held for writing across the writes of member and parent edges, so that
+	// the index follows them in the order of the repository
+	mu      sync.RWMutex
+	dbRepo  domain.DbRepository
+	tenants map[string]*index
+}
+
+func NewClosure(dbRepo domain.DbRepository) *Closure {
+	return &Closure{
+		dbRepo:  dbRepo,
+		tenants: map[string]*index{},
+	}
+}
+
+// index holds the member and parent edges of a tenant and the closure of the
+// ones without a period, whose effect does not depend on the time.
+type index struct {
+	// the period of the edge from each user or role to each role
+	edges map[domain.Vertex]map[string]domain.Period
+	// the roles each user or role reaches through edges without a period
+	roles map[domain.Vertex]map[string]struct{}
+	// the users and roles reaching each role
+	holders map[string]map[domain.Vertex]struct{}
+}
+
+func newIndex() *index {
+	return &index{
+		edges:   map[domain.Vertex]map[string]domain.Period{},
+		roles:   map[domain.Vertex]map[string]struct{}{},
+		holders: map[string]map[domain.Vertex]struct{}{},
+	}
+}
+
+// indexed reports whether the edge is a member or parent edge the closure
+// follows.
+func indexed(edge domain.Edge) bool {
+	return edge.VNs == "role" &&
+		(edge.Rel == "member" && edge.UNs == "user" ||
+			edge.Rel == "parent" && edge.UNs == "role")
+}
+
+func timed(p domain.Period) bool {
+	return p.NotBefore != nil || p.ExpiresAt != nil
+}
+
+func role(name string) domain.Vertex {
+	return domain.Vertex{Ns: "role", Name: name}
+}
+
+func (ix *index) has(edge domain.Edge) bool {
+	_, ok := ix.edges[domain.Vertex{Ns: edge.UNs, Name: edge.UName}][edge.VName]
+	return ok
+}
+
+// add extends the roles of the edge's subject and of everyone reaching it.
+func (ix *index) add(edge domain.Edge) {
+	u := domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+	if ix.has(edge) {
+		ix.remove(edge)
+	}
+	if ix.edges[u] == nil {
+		ix.edges[u] = map[string]domain.Period{}
+	}
+	ix.edges[u][edge.VName] = edge.Period
+	if timed(edge.Period) {
+		return
+	}
+
+	gained := []string{edge.VName}
+	for r := range ix.roles[role(edge.VName)] {
+		gained = append(gained, r)
+	}
+	for _, x := range ix.reaching(u) {
+		for _, r := range gained {
+			ix.hold(x, r)
+		}
+	}
+}
+
+// remove recomputes the roles of the edge's subject and of everyone
+// reaching it, another path may still lead to the roles of the edge.
+func (ix *index) remove(edge domain.Edge) {
+	u := domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+	p, ok := ix.edges[u][edge.VName]
+	if !ok {
+		return
+	}
+	delete(ix.edges[u], edge.VName)
+	if len(ix.edges[u]) == 0 {
+		delete(ix.edges, u)
+	}
+	if timed(p) {
+		return
+	}
+
+	for _, x := range ix.reaching(u) {
+		reached := ix.reach(x)
+		for r := range ix.roles[x] {
+			if _, ok := reached[r]; !ok {
+				ix.release(x, r)
+			}
+		}
+	}
+}
+
+// reaching returns v and the users and roles reaching it.
+func (ix *index) reaching(v domain.Vertex) []domain.Vertex {
+	xs := []domain.Vertex{v}
+	if v.Ns != "role" {
+		return xs
+	}
+	for x := range ix.holders[v.Name] {
+		if x != v {
+			xs = append(xs, x)
+		}
+	}
+	return xs
+}
+
+// reach walks the edges without a period from v.
+func (ix *index) reach(v domain.Vertex) map[string]struct{} {
+	reached := map[string]struct{}{}
+	stack := []domain.Vertex{v}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for r, p := range ix.edges[u] {
+			if _, ok := reached[r]; ok || timed(p) {
+				continue
+			}
+			reached[r] = struct{}{}
+			stack = append(stack, role(r))
+		}
+	}
+	return reached
+}
+
+func (ix *index) hold(x domain.Vertex, r string) {
+	if ix.roles[x] == nil {
+		ix.roles[x] = map[string]struct{}{}
+	}
+	ix.roles[x][r] = struct{}{}
+	if ix.holders[r] == nil {
+		ix.holders[r] = map[domain.Vertex]struct{}{}
+	}
+	ix.holders[r][x] = struct{}{}
+}
+
+func (ix *index) release(x domain.Vertex, r string) {
+	delete(ix.roles[x], r)
+	if len(ix.roles[x]) == 0 {
+		delete(ix.roles, x)
+	}
+	delete(ix.holders[r], x)
+	if len(ix.holders[r]) == 0 {
+		delete(ix.holders, r)
+	}
+}
+
+// each calls fn with every edge of the index.
+func (ix *index) each(fn func(edge domain.Edge)) {
+	for u, roles := range ix.edges {
+		rel := "member"
+		if u.Ns == "role" {
+			rel = "parent"
+		}
+		for r, p := range roles {
+			fn(domain.Edge{UNs: u.Ns, UName: u.Name, Rel: rel, VNs: "role",
+				VName: r, Period: p})
+		}
+	}
+}
+
+// removeMatching removes the edges a delete in query mode with filter
+// removes.
+func (ix *index) removeMatching(filter domain.Edge) {
+	matched := []domain.Edge{}
+	ix.each(func(edge domain.Edge) {
+		if (filter.UNs == "" || edge.UNs == filter.UNs) &&
+			(filter.UName == "" || edge.UName == filter.UName) &&
+			(filter.Rel == "" || edge.Rel == filter.Rel) &&
+			(filter.VNs == "" || edge.VNs == filter.VNs) &&
+			(filter.VName == "" || edge.VName == filter.VName) {
+			matched = append(matched, edge)
+		}
+	})
+	for _, edge := range matched {
+		ix.remove(edge)
+	}
+}
+
+func (ix *index) removeExpired(now time.Time) {
+	expired := []domain.Edge{}
+	ix.each(func(edge domain.Edge) {
+		if edge.ExpiresAt != nil && !edge.ExpiresAt.After(now) {
+			expired = append(expired, edge)
+		}
+	})
+	for _, edge := range expired {
+		ix.remove(edge)
+	}
+}
+
+// held returns v and the roles it holds, and whether they are all it
+// reaches: an edge with a period leaving them may reach more at times.
+func (ix *index) held(v domain.Vertex) (map[domain.Vertex]struct{}, bool) {
+	held := map[domain.Vertex]struct{}{v: {}}
+	for r := range ix.roles[v] {
+		held[role(r)] = struct{}{}
+	}
+	for x := range held {
+		for _, p := range ix.edges[x] {
+			if timed(p) {
+				return held, false
+			}
+		}
+	}
+	return held, true
+}
+
+// build reads the member and parent edges of the tenant of c.
+func (cl *Closure) build(c context.Context) (*index, error) {
+	ix := newIndex()
+	for _, rel := range []string{"member", "parent"} {
+		edges, err := cl.dbRepo.Get(c, domain.Edge{Rel: rel}, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range edges {
+			if indexed(edge) {
+				ix.add(edge)
+			}
+		}
+	}
+	return ix, nil
+}
+
+// read calls fn with the index of the tenant of c, built if needed.
+func (cl *Closure) read(c context.Context, fn func(ix *index)) error {
+	tenant := domain.TenantFrom(c)
+	cl.mu.RLock()
+	if ix, ok := cl.tenants[tenant]; ok {
+		defer cl.mu.RUnlock()
+		fn(ix)
+		return nil
+	}
+	cl.mu.RUnlock()
+
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	ix, ok := cl.tenants[tenant]
+	if !ok {
+		var err error
+		if ix, err = cl.build(c); err != nil {
+			return err
+		}
+		cl.tenants[tenant] = ix
+	}
+	fn(ix)
+	return nil
+}
+
+// write runs the write and then update on the index of the tenant of c,
+// unless it is not built yet. A failed write leaves the index alone.
+func (cl *Closure) write(c context.Context, write func() error,
+	update func(ix *index)) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if err := write(); err != nil {
+		return err
+	}
+	if ix, ok := cl.tenants[domain.TenantFrom(c)]; ok {
+		update(ix)
+	}
+	return nil
+}
+
+// forget drops the index of the tenant of c, to be built again on next use.
+func (cl *Closure) forget(c context.Context) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	delete(cl.tenants, domain.TenantFrom(c))
+}
+
+// Rebuild replaces the index of the tenant of c with one read from its edges.
+func (cl *Closure) Rebuild(c context.Context) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	ix, err := cl.build(c)
+	if err != nil {
+		return err
+	}
+	cl.tenants[domain.TenantFrom(c)] = ix
+	return nil
+}
+
+// Verify compares the index of the tenant of c with one read from its edges.
+func (cl *Closure) Verify(c context.Context) (domain.ClosureReport, error) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	report := domain.ClosureReport{
+		Enabled: true,
+		Missing: []domain.ClosureEntry{},
+		Extra:   []domain.ClosureEntry{},
+	}
+	want, err := cl.build(c)
+	if err != nil {
+		return report, err
+	}
+	got, ok := cl.tenants[domain.TenantFrom(c)]
+	if !ok {
+		got = want
+		cl.tenants[domain.TenantFrom(c)] = want
+	}
+
+	entries := func(ix *index) map[domain.ClosureEntry]struct{} {
+		res := map[domain.ClosureEntry]struct{}{}
+		for x, roles := range ix.roles {
+			for r := range roles {
+				res[domain.ClosureEntry{Sbj: x, Role: r}] = struct{}{}
+			}
+		}
+		ix.each(func(edge domain.Edge) {
+			if timed(edge.Period) {
+				res[domain.ClosureEntry{
+					Sbj:   domain.Vertex{Ns: edge.UNs, Name: edge.UName},
+					Role:  edge.VName,
+					Timed: true,
+				}] = struct{}{}
+			}
+		})
+		return res
+	}
+	wantEntries, gotEntries := entries(want), entries(got)
+	for e := range wantEntries {
+		if _, ok := gotEntries[e]; !ok {
+			report.Missing = append(report.Missing, e)
+		}
+	}
+	for e := range gotEntries {
+		if _, ok := wantEntries[e]; !ok {
+			report.Extra = append(report.Extra, e)
+		}
+		if !e.Timed {
+			report.Entries++
+		}
+	}
+	sortEntries(report.Missing)
+	sortEntries(report.Extra)
+	report.Consistent = len(report.Missing) == 0 && len(report.Extra) == 0
+	return report, nil
+}
+
+func sortEntries(entries []domain.ClosureEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.Sbj.Ns != b.Sbj.Ns:
+			return a.Sbj.Ns < b.Sbj.Ns
+		case a.Sbj.Name != b.Sbj.Name:
+			return a.Sbj.Name < b.Sbj.Name
+		case a.Role != b.Role:
+			return a.Role < b.Role
+		}
+		return !a.Timed && b.Timed
+	})
+}
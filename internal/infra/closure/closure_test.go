@@ -0,0 +1,220 @@
+package closure_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/closure"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// counted counts the queries of the traversals.
+type counted struct {
+	domain.DbRepository
+	queries int
+}
+
+func (r *counted) Get(c context.Context, filter domain.Edge, queryMode bool) (
+	[]domain.Edge, error) {
+	r.queries++
+	return r.DbRepository.Get(c, filter, queryMode)
+}
+
+func (r *counted) GetNeighbors(c context.Context, vertices []domain.Vertex,
+	isSbj bool) ([]domain.Edge, error) {
+	r.queries++
+	return r.DbRepository.GetNeighbors(c, vertices, isSbj)
+}
+
+func member(user string, role string) domain.Edge {
+	return domain.Edge{UNs: "user", UName: user, Rel: "member", VNs: "role",
+		VName: role}
+}
+
+func inherit(parent string, child string) domain.Edge {
+	return domain.Edge{UNs: "role", UName: parent, Rel: "parent", VNs: "role",
+		VName: child}
+}
+
+func TestClosureFollowsWrites(t *testing.T) {
+	c := context.Background()
+	inner := memory.NewMemoryRepository()
+	cl := closure.NewClosure(inner)
+	repo := cl.Repository(inner)
+	assert.NoError(t, cl.Rebuild(c))
+
+	rnd := rand.New(rand.NewSource(1))
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	edge := func() domain.Edge {
+		e := inherit(fmt.Sprint(rnd.Intn(6)), fmt.Sprint(rnd.Intn(6)))
+		if rnd.Intn(3) == 0 {
+			e = member(fmt.Sprint(rnd.Intn(3)), fmt.Sprint(rnd.Intn(6)))
+		}
+		switch rnd.Intn(8) {
+		case 0:
+			e.ExpiresAt = &past
+		case 1:
+			e.NotBefore = &future
+		}
+		return e
+	}
+	for step := 0; step < 300; step++ {
+		switch rnd.Intn(10) {
+		case 0:
+			_ = repo.Delete(c, domain.Edge{UNs: "role",
+				UName: fmt.Sprint(rnd.Intn(6))}, true)
+		case 1:
+			_ = repo.Delete(c, domain.Edge{VNs: "role",
+				VName: fmt.Sprint(rnd.Intn(6))}, true)
+		case 2:
+			_ = repo.ApplyOperations(c, []domain.Operation{
+				{Type: domain.CreateIfNotExistOperation, Edge: edge()},
+				{Type: domain.CreateOperation, Edge: domain.Edge{UNs: "role",
+					UName: "0", Rel: "read", VNs: "file", VName: "a"}},
+				{Type: domain.DeleteOperation, Edge: edge()},
+			})
+		case 3:
+			_, _ = repo.DeleteExpired(c, time.Now())
+		case 4, 5, 6:
+			_ = repo.Delete(c, edge(), false)
+		default:
+			_ = repo.Create(c, edge())
+		}
+		report, err := cl.Verify(c)
+		assert.NoError(t, err)
+		if !assert.True(t, report.Consistent, "step %d: %+v", step, report) {
+			return
+		}
+	}
+}
+
+func TestClosureVerify(t *testing.T) {
+	c := context.Background()
+	inner := memory.NewMemoryRepository()
+	cl := closure.NewClosure(inner)
+	repo := cl.Repository(inner)
+	assert.NoError(t, repo.Create(c, member("alice", "admin")))
+	assert.NoError(t, repo.Create(c, inherit("admin", "viewer")))
+
+	report, err := cl.Verify(c)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ClosureReport{Enabled: true, Consistent: true,
+		Entries: 3, Missing: []domain.ClosureEntry{},
+		Extra: []domain.ClosureEntry{}}, report)
+
+	// a write the closure did not see
+	assert.NoError(t, inner.Create(c, inherit("viewer", "guest")))
+	report, err = cl.Verify(c)
+	assert.NoError(t, err)
+	assert.False(t, report.Consistent)
+	assert.Equal(t, []domain.ClosureEntry{
+		{Sbj: domain.Vertex{Ns: "role", Name: "admin"}, Role: "guest"},
+		{Sbj: domain.Vertex{Ns: "role", Name: "viewer"}, Role: "guest"},
+		{Sbj: domain.Vertex{Ns: "user", Name: "alice"}, Role: "guest"},
+	}, report.Missing)
+
+	assert.NoError(t, cl.Rebuild(c))
+	report, err = cl.Verify(c)
+	assert.NoError(t, err)
+	assert.True(t, report.Consistent)
+}
+
+func TestClosureCheck(t *testing.T) {
+	c := context.Background()
+	inner := memory.NewMemoryRepository()
+	cl := closure.NewClosure(inner)
+	repo := cl.Repository(inner)
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, repo.Create(c, inherit(fmt.Sprint(i),
+			fmt.Sprint(i+1))))
+	}
+	assert.NoError(t, repo.Create(c, member("alice", "0")))
+	assert.NoError(t, repo.Create(c, domain.Edge{UNs: "role", UName: "20",
+		Rel: "read", VNs: "file", VName: "a"}))
+	r := &counted{DbRepository: repo}
+	schemaInfra := schema.NewSchemaInfra()
+	g := cl.Graph(graph.NewGraphInfra(r, schemaInfra), schemaInfra)
+	alice := domain.Vertex{Ns: "user", Name: "alice"}
+	file := domain.Vertex{Ns: "file", Name: "a"}
+
+	ok, err := g.Check(c, alice, file, "read", domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 0, r.queries)
+
+	// the inheritance is cut in the middle of the hierarchy
+	assert.NoError(t, repo.Delete(c, inherit("10", "11"), false))
+	ok, err = g.Check(c, alice, file, "read", domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, r.queries)
+}
+
+// TestClosureCheckMatchesWalk compares the checks answered by the closure
+// with the ones of the BFS on random graphs.
+func TestClosureCheckMatchesWalk(t *testing.T) {
+	c := context.Background()
+	rnd := rand.New(rand.NewSource(1))
+	past := time.Now().Add(-time.Hour)
+	name := func() string { return fmt.Sprint(rnd.Intn(4)) }
+	rels := []string{"read", domain.DenyRel("read"), "editor", "viewer"}
+	schemaInfra := schema.NewSchemaInfra()
+	assert.NoError(t, schemaInfra.SetSchema(c, domain.Schema{
+		Namespaces: []domain.NamespaceConfig{{
+			Name: "doc",
+			Relations: []domain.RelationConfig{
+				{Name: "editor"},
+				{Name: "viewer", ComputedUsersets: []string{"editor"}},
+			},
+		}},
+	}))
+
+	for round := 0; round < 20; round++ {
+		inner := memory.NewMemoryRepository()
+		cl := closure.NewClosure(inner)
+		repo := cl.Repository(inner)
+		for i := 0; i < 40; i++ {
+			e := domain.Edge{UNs: []string{"user", "role", "file"}[rnd.Intn(3)],
+				UName: name(), Rel: rels[rnd.Intn(len(rels))],
+				VNs: []string{"file", "doc"}[rnd.Intn(2)], VName: name()}
+			switch rnd.Intn(3) {
+			case 0:
+				e = member(name(), name())
+			case 1:
+				e = inherit(name(), name())
+			}
+			if rnd.Intn(20) == 0 {
+				e.ExpiresAt = &past
+			}
+			_ = repo.Create(c, e)
+		}
+		want := graph.NewGraphInfra(repo, schemaInfra)
+		got := cl.Graph(want, schemaInfra)
+		for _, sbjNs := range []string{"user", "role"} {
+			for _, objNs := range []string{"file", "doc"} {
+				for i := 0; i < 16; i++ {
+					sbj := domain.Vertex{Ns: sbjNs, Name: fmt.Sprint(i / 4)}
+					obj := domain.Vertex{Ns: objNs, Name: fmt.Sprint(i % 4)}
+					for _, rel := range []string{"read", "editor", "viewer"} {
+						wantOk, err := want.Check(c, sbj, obj, rel,
+							domain.SearchCond{})
+						assert.NoError(t, err)
+						gotOk, err := got.Check(c, sbj, obj, rel,
+							domain.SearchCond{})
+						assert.NoError(t, err)
+						assert.Equal(t, wantOk, gotOk, "round %d: %v %s %v",
+							round, sbj, rel, obj)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package closure
+
+import (
+	"context"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// GraphInfra answers the checks of users and roles from the closure and a
+// query of the edges into the object. Edges only lead into a role from a
+// user or a role and never into a user, so the users and roles reached from
+// the subject are the ones the closure holds. The checks it cannot answer
+// exactly go to the inner GraphInfra: an edge with a period leaves those
+// roles, a subject of another namespace holds the relation on the object, or
+// the schema rewrites it.
+type GraphInfra struct {
+	domain.GraphInfra
+	closure     *Closure
+	schemaInfra domain.SchemaInfra
+}
+
+func (cl *Closure) Graph(inner domain.GraphInfra,
+	schemaInfra domain.SchemaInfra) *GraphInfra {
+	return &GraphInfra{GraphInfra: inner, closure: cl, schemaInfra: schemaInfra}
+}
+
+func (g *GraphInfra) Check(c context.Context, start domain.Vertex,
+	target domain.Vertex, relation string, searchCond domain.SearchCond) (
+	bool, error) {
+	in := searchCond.In
+	if start.Ns != "user" && start.Ns != "role" ||
+		len(in.Nses) > 0 || len(in.Names) > 0 || len(in.Rels) > 0 {
+		return g.GraphInfra.Check(c, start, target, relation, searchCond)
+	}
+	var (
+		held  map[domain.Vertex]struct{}
+		exact bool
+	)
+	if err := g.closure.read(c, func(ix *index) {
+		held, exact = ix.held(start)
+	}); err != nil {
+		return false, err
+	}
+	if !exact {
+		return g.GraphInfra.Check(c, start, target, relation, searchCond)
+	}
+
+	edges, err := g.closure.dbRepo.Get(c, domain.Edge{
+		VNs:   target.Ns,
+		VName: target.Name,
+	}, true)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	granted, unknown := false, false
+	for _, edge := range edges {
+		if !edge.Active(now) ||
+			edge.Rel != relation && edge.Rel != domain.DenyRel(relation) {
+			continue
+		}
+		sbj := domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+		if sbj.Ns != "user" && sbj.Ns != "role" {
+			unknown = true
+			continue
+		}
+		if _, ok := held[sbj]; !ok {
+			continue
+		}
+		if edge.Rel != relation {
+			// denies override grants
+			return false, nil
+		}
+		granted = true
+	}
+
+	config, rewritten := g.schemaInfra.Relation(c, target.Ns, relation)
+	rewritten = rewritten && (len(config.ComputedUsersets) > 0 ||
+		len(config.TupleToUsersets) > 0)
+	switch {
+	case unknown || !granted && rewritten:
+		return g.GraphInfra.Check(c, start, target, relation, searchCond)
+	default:
+		return granted, nil
+	}
+}
+
+func (g *GraphInfra) Rebuild(c context.Context) error {
+	return g.closure.Rebuild(c)
+}
+
+func (g *GraphInfra) Verify(c context.Context) (domain.ClosureReport, error) {
+	return g.closure.Verify(c)
+}
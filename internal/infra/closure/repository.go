@@ -0,0 +1,109 @@
+package closure
+
+import (
+	"context"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// Repository keeps the closure up to date with the member and parent edges
+// written through it.
+type Repository struct {
+	domain.DbRepository
+	closure *Closure
+}
+
+func (cl *Closure) Repository(inner domain.DbRepository) *Repository {
+	return &Repository{DbRepository: inner, closure: cl}
+}
+
+func (r *Repository) Create(c context.Context, edge domain.Edge) error {
+	if !indexed(edge) {
+		return r.DbRepository.Create(c, edge)
+	}
+	return r.closure.write(c, func() error {
+		return r.DbRepository.Create(c, edge)
+	}, func(ix *index) {
+		ix.add(edge)
+	})
+}
+
+// Delete in query mode drops the index of the tenant when it fails, the
+// edges it removed are not known.
+func (r *Repository) Delete(c context.Context, edge domain.Edge,
+	queryMode bool) error {
+	if !queryMode {
+		if !indexed(edge) {
+			return r.DbRepository.Delete(c, edge, false)
+		}
+		return r.closure.write(c, func() error {
+			return r.DbRepository.Delete(c, edge, false)
+		}, func(ix *index) {
+			ix.remove(edge)
+		})
+	}
+	err := r.closure.write(c, func() error {
+		return r.DbRepository.Delete(c, edge, true)
+	}, func(ix *index) {
+		ix.removeMatching(edge)
+	})
+	if err != nil {
+		r.closure.forget(c)
+	}
+	return err
+}
+
+func (r *Repository) ApplyOperations(c context.Context,
+	operations []domain.Operation) error {
+	touched := false
+	for _, op := range operations {
+		touched = touched || indexed(op.Edge)
+	}
+	if !touched {
+		return r.DbRepository.ApplyOperations(c, operations)
+	}
+	return r.closure.write(c, func() error {
+		return r.DbRepository.ApplyOperations(c, operations)
+	}, func(ix *index) {
+		for _, op := range operations {
+			if !indexed(op.Edge) {
+				continue
+			}
+			switch op.Type {
+			case domain.CreateOperation:
+				ix.add(op.Edge)
+			case domain.CreateIfNotExistOperation:
+				// an existing edge keeps its period
+				if !ix.has(op.Edge) {
+					ix.add(op.Edge)
+				}
+			case domain.DeleteOperation:
+				ix.remove(op.Edge)
+			}
+		}
+	})
+}
+
+func (r *Repository) DeleteExpired(c context.Context, now time.Time) (
+	int64, error) {
+	r.closure.mu.Lock()
+	defer r.closure.mu.Unlock()
+	deleted, err := r.DbRepository.DeleteExpired(c, now)
+	if err != nil {
+		// the edges it removed are not known
+		r.closure.tenants = map[string]*index{}
+		return deleted, err
+	}
+	for _, ix := range r.closure.tenants {
+		ix.removeExpired(now)
+	}
+	return deleted, nil
+}
+
+func (r *Repository) ClearAll(c context.Context) error {
+	r.closure.mu.Lock()
+	defer r.closure.mu.Unlock()
+	r.closure.tenants = map[string]*index{}
+	return r.DbRepository.ClearAll(c)
+}
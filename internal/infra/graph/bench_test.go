@@ -0,0 +1,148 @@
+package graph_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+)
+
+// roundTrip is the latency of a query to the database.
+const roundTrip = 100 * time.Microsecond
+
+// remote delays every query by a round-trip and counts them, and the edges
+// they fetched.
+type remote struct {
+	domain.DbRepository
+	queries int
+	fetched int
+}
+
+func (r *remote) Get(c context.Context, filter domain.Edge, queryMode bool) (
+	[]domain.Edge, error) {
+	r.queries++
+	time.Sleep(roundTrip)
+	edges, err := r.DbRepository.Get(c, filter, queryMode)
+	r.fetched += len(edges)
+	return edges, err
+}
+
+func (r *remote) GetNeighbors(c context.Context, vertices []domain.Vertex,
+	isSbj bool) ([]domain.Edge, error) {
+	r.queries++
+	time.Sleep(roundTrip)
+	edges, err := r.DbRepository.GetNeighbors(c, vertices, isSbj)
+	r.fetched += len(edges)
+	return edges, err
+}
+
+// perVertex expands a frontier with a query per vertex, as the traversals
+// did before GetNeighbors.
+type perVertex struct {
+	*remote
+}
+
+func (r perVertex) GetNeighbors(c context.Context, vertices []domain.Vertex,
+	isSbj bool) ([]domain.Edge, error) {
+	edges := []domain.Edge{}
+	for _, v := range vertices {
+		filter := domain.Edge{VNs: v.Ns, VName: v.Name}
+		if isSbj {
+			filter = domain.Edge{UNs: v.Ns, UName: v.Name}
+		}
+		res, err := r.Get(c, filter, true)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, res...)
+	}
+	return edges, nil
+}
+
+// hierarchy stores a user member of fanout roles, each role inheriting
+// fanout others down to depth levels, and the leaves reading a file each.
+func hierarchy(b *testing.B, fanout int, depth int) domain.DbRepository {
+	c := context.Background()
+	repo := memory.NewMemoryRepository()
+	create := func(edge domain.Edge) {
+		if err := repo.Create(c, edge); err != nil {
+			b.Fatal(err)
+		}
+	}
+	level := []string{}
+	for i := 0; i < fanout; i++ {
+		role := fmt.Sprintf("r%d", i)
+		create(domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+			VNs: "role", VName: role})
+		level = append(level, role)
+	}
+	for d := 1; d < depth; d++ {
+		next := []string{}
+		for _, parent := range level {
+			for i := 0; i < fanout; i++ {
+				child := fmt.Sprintf("%s.%d", parent, i)
+				create(inherit(parent, child))
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+	for _, role := range level {
+		create(domain.Edge{UNs: "role", UName: role, Rel: "read", VNs: "file",
+			VName: role})
+	}
+	return repo
+}
+
+func BenchmarkTraversals(b *testing.B) {
+	repo := hierarchy(b, 3, 5)
+	alice := domain.Vertex{Ns: "user", Name: "alice"}
+	// granted by the last leaf, so that the whole hierarchy is walked
+	target := domain.Vertex{Ns: "file", Name: "r2.2.2.2.2"}
+	traversals := []struct {
+		name string
+		run  func(g *graph.GraphInfra) error
+	}{
+		{"check", func(g *graph.GraphInfra) error {
+			_, err := g.Check(context.Background(), alice, target, "read",
+				domain.SearchCond{})
+			return err
+		}},
+		{"search permissions", func(g *graph.GraphInfra) error {
+			_, err := g.SearchPermissions(context.Background(), alice, true,
+				domain.SearchCond{}, domain.CollectCond{}, math.MaxInt)
+			return err
+		}},
+	}
+	repos := []struct {
+		name string
+		repo func(r *remote) domain.DbRepository
+	}{
+		{"per vertex", func(r *remote) domain.DbRepository {
+			return perVertex{r}
+		}},
+		{"batched", func(r *remote) domain.DbRepository { return r }},
+	}
+
+	for _, tr := range traversals {
+		for _, rp := range repos {
+			b.Run(tr.name+"/"+rp.name, func(b *testing.B) {
+				r := &remote{DbRepository: repo}
+				g := graph.NewGraphInfra(rp.repo(r), schema.NewSchemaInfra())
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if err := tr.run(g); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(r.queries)/float64(b.N), "queries/op")
+			})
+		}
+	}
+}
@@ -2,149 +2,504 @@ package graph
 
 import (
 	"context"
+	"time"
 
 	"github.com/skyrocketOoO/RBAC-server/domain"
-	"github.com/skyrocketOoO/go-utility/queue"
 	"github.com/skyrocketOoO/go-utility/set"
 )
 
 type GraphInfra struct {
-	dbRepo domain.DbRepository
+	dbRepo      domain.DbRepository
+	schemaInfra domain.SchemaInfra
 }
 
-func NewGraphInfra(dbRepo domain.DbRepository) *GraphInfra {
+func NewGraphInfra(dbRepo domain.DbRepository,
+	schemaInfra domain.SchemaInfra) *GraphInfra {
 	return &GraphInfra{
-		dbRepo: dbRepo,
+		dbRepo:      dbRepo,
+		schemaInfra: schemaInfra,
 	}
 }
 
+// Check reports whether start holds relation on target through the stored
+// edges or the rewrite rules of the namespace schema. Denies override grants.
+// Each permission is searched from both ends, see meet.
 func (g *GraphInfra) Check(c context.Context, start domain.Vertex, target domain.Vertex,
 	relation string, searchCond domain.SearchCond) (bool, error) {
+	seen := set.NewSet[domain.Permission]()
+	return g.evaluate(c, domain.Permission{
+		Rel:  relation,
+		Ns:   target.Ns,
+		Name: target.Name,
+	}, func(p domain.Permission) (bool, bool, error) {
+		denied, err := g.reaches(c, start, domain.Permission{
+			Rel:  domain.DenyRel(p.Rel),
+			Ns:   p.Ns,
+			Name: p.Name,
+		}, searchCond)
+		if err != nil || denied {
+			return false, denied, err
+		}
+		granted, err := g.reaches(c, start, p, searchCond)
+		return granted, false, err
+	}, &seen)
+}
+
+// reaches reports whether start walks to an edge granting p.
+func (g *GraphInfra) reaches(c context.Context, start domain.Vertex,
+	p domain.Permission, searchCond domain.SearchCond) (bool, error) {
+	// the last hop has to be the relation itself
+	edges, err := g.getActive(c, domain.Edge{
+		Rel:   p.Rel,
+		VNs:   p.Ns,
+		VName: p.Name,
+	})
+	if err != nil || len(edges) == 0 {
+		return false, err
+	}
+	sources := make([]domain.Vertex, len(edges))
+	for i, edge := range edges {
+		sources[i] = domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+	}
+	return g.meet(c, start, sources, searchCond)
+}
+
+// meet searches forward from start and backward from the sources at once,
+// expanding the smaller frontier each step, and reports whether they meet.
+// Deny edges are not walked, and neither are the ones searchCond stops at.
+func (g *GraphInfra) meet(c context.Context, start domain.Vertex,
+	sources []domain.Vertex, searchCond domain.SearchCond) (bool, error) {
+	forward := set.NewSet[domain.Vertex]()
+	forward.Add(start)
+	backward := set.NewSet[domain.Vertex]()
+	for _, v := range sources {
+		if v == start {
+			return true, nil
+		}
+		backward.Add(v)
+	}
+
+	fFrontier := []domain.Vertex{start}
+	bFrontier := backward.ToSlice()
+	for len(fFrontier) > 0 && len(bFrontier) > 0 {
+		isSbj := len(fFrontier) <= len(bFrontier)
+		frontier, visited, other := bFrontier, &backward, &forward
+		if isSbj {
+			frontier, visited, other = fFrontier, &forward, &backward
+		}
+		edges, err := g.expand(c, frontier, isSbj)
+		if err != nil {
+			return false, err
+		}
+
+		next := []domain.Vertex{}
+		for _, edge := range edges {
+			if _, deny := domain.DeniedRel(edge.Rel); deny ||
+				searchCond.ShouldStop(edge, true) {
+				continue
+			}
+			v := domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+			if isSbj {
+				v = domain.Vertex{Ns: edge.VNs, Name: edge.VName}
+			}
+			if other.Exist(v) {
+				return true, nil
+			}
+			if !visited.Exist(v) {
+				visited.Add(v)
+				next = append(next, v)
+			}
+		}
+		if isSbj {
+			fFrontier = next
+		} else {
+			bFrontier = next
+		}
+	}
+	return false, nil
+}
+
+// walk returns every permission reached from start through stored edges and
+// the ones revoked by deny edges on the way.
+func (g *GraphInfra) walk(c context.Context, start domain.Vertex,
+	searchCond domain.SearchCond) (granted set.Set[domain.Permission],
+	denied set.Set[domain.Permission], err error) {
+	granted = set.NewSet[domain.Permission]()
+	denied = set.NewSet[domain.Permission]()
 	visited := set.NewSet[domain.Vertex]()
-	q := queue.NewQueue[domain.Vertex]()
 	visited.Add(start)
-	q.Push(start)
-	for !q.IsEmpty() {
-		qLen := q.Len()
-		for i := 0; i < qLen; i++ {
-			vertex, _ := q.Pop()
-			edges, err := g.dbRepo.Get(c, domain.Edge{
-				UNs:   vertex.Ns,
-				UName: vertex.Name,
-			}, true)
-			if err != nil {
-				return false, err
+	frontier := []domain.Vertex{start}
+	for len(frontier) > 0 {
+		edges, err := g.expand(c, frontier, true)
+		if err != nil {
+			return granted, denied, err
+		}
+
+		frontier = []domain.Vertex{}
+		for _, edge := range edges {
+			p := domain.Permission{
+				Rel:  edge.Rel,
+				Ns:   edge.VNs,
+				Name: edge.VName,
+			}
+			if rel, deny := domain.DeniedRel(edge.Rel); deny {
+				p.Rel = rel
+				denied.Add(p)
+				continue
 			}
+			granted.Add(p)
+			child := domain.Vertex{
+				Ns:   edge.VNs,
+				Name: edge.VName,
+			}
+			if !searchCond.ShouldStop(edge, true) && !visited.Exist(child) {
+				visited.Add(child)
+				frontier = append(frontier, child)
+			}
+		}
+	}
 
-			for _, edge := range edges {
-				if edge.VNs == target.Ns && edge.VName == target.Name &&
-					edge.Rel == relation {
-					return true, nil
-				}
-				child := domain.Vertex{
-					Ns:   edge.VNs,
-					Name: edge.VName,
+	return granted, denied, nil
+}
+
+// holds tells whether the subject is granted or denied p by the stored edges.
+type holds func(p domain.Permission) (granted bool, denied bool, err error)
+
+// inSets holds the permissions of granted which are not in denied.
+func inSets(granted *set.Set[domain.Permission],
+	denied *set.Set[domain.Permission]) holds {
+	return func(p domain.Permission) (bool, bool, error) {
+		return granted.Exist(p), denied.Exist(p), nil
+	}
+}
+
+// Evaluate reports whether the subject whose walk granted and denied the
+// permissions holds p, expanding the rewrite rules of the schema. It lets
+// another engine walk the graph and reuse the rules.
+func (g *GraphInfra) Evaluate(c context.Context, p domain.Permission,
+	granted set.Set[domain.Permission], denied set.Set[domain.Permission]) (
+	bool, error) {
+	seen := set.NewSet[domain.Permission]()
+	return g.evaluate(c, p, inSets(&granted, &denied), &seen)
+}
+
+// evaluate reports whether p is held and not denied, expanding the rewrite
+// rules of p's namespace when it is not held directly. seen stops rules
+// which refer back to each other.
+func (g *GraphInfra) evaluate(c context.Context, p domain.Permission,
+	held holds, seen *set.Set[domain.Permission]) (bool, error) {
+	if seen.Exist(p) {
+		return false, nil
+	}
+	seen.Add(p)
+	granted, denied, err := held(p)
+	if err != nil || denied {
+		return false, err
+	}
+	if granted {
+		return true, nil
+	}
+
+	config, ok := g.schemaInfra.Relation(c, p.Ns, p.Rel)
+	if !ok {
+		return false, nil
+	}
+	for _, rel := range config.ComputedUsersets {
+		ok, err := g.evaluate(c, domain.Permission{
+			Rel:  rel,
+			Ns:   p.Ns,
+			Name: p.Name,
+		}, held, seen)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	for _, ttu := range config.TupleToUsersets {
+		edges, err := g.getActive(c, domain.Edge{
+			Rel:   ttu.Tupleset,
+			VNs:   p.Ns,
+			VName: p.Name,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, edge := range edges {
+			ok, err := g.evaluate(c, domain.Permission{
+				Rel:  ttu.ComputedUserset,
+				Ns:   edge.UNs,
+				Name: edge.UName,
+			}, held, seen)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// Explain walks like Check but remembers how every vertex was reached. On
+// denial it returns the path of the deny edge if there is one, the paths that
+// reached the target with another relation, or else the paths to the farthest
+// vertices within maxDepth. Only stored edges are explained, the rewrite rules
+// of the schema are not expanded.
+func (g *GraphInfra) Explain(c context.Context, start domain.Vertex,
+	target domain.Vertex, relation string, searchCond domain.SearchCond,
+	maxDepth int) (*domain.Explanation, error) {
+	via := map[domain.Vertex]domain.Edge{}
+	pathTo := func(last domain.Edge) []domain.Edge {
+		path := []domain.Edge{last}
+		u := domain.Vertex{Ns: last.UNs, Name: last.UName}
+		for u != start {
+			edge := via[u]
+			path = append([]domain.Edge{edge}, path...)
+			u = domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+		}
+		return path
+	}
+
+	var grant []domain.Edge
+	near := []domain.Edge{}
+	farthest := []domain.Vertex{}
+	visited := set.NewSet[domain.Vertex]()
+	visited.Add(start)
+	frontier := []domain.Vertex{start}
+	for depth := 0; depth < maxDepth && len(frontier) > 0; depth++ {
+		edges, err := g.expand(c, frontier, true)
+		if err != nil {
+			return nil, err
+		}
+
+		frontier = []domain.Vertex{}
+		for _, edge := range edges {
+			if edge.VNs == target.Ns && edge.VName == target.Name {
+				switch edge.Rel {
+				case domain.DenyRel(relation):
+					return &domain.Explanation{
+						Granted: false,
+						Paths:   [][]domain.Edge{pathTo(edge)},
+					}, nil
+				case relation:
+					if grant == nil {
+						grant = pathTo(edge)
+					}
+				default:
+					near = append(near, edge)
 				}
-				if !visited.Exist(child) {
-					visited.Add(child)
-					q.Push(child)
+			}
+			if _, deny := domain.DeniedRel(edge.Rel); deny {
+				continue
+			}
+			child := domain.Vertex{
+				Ns:   edge.VNs,
+				Name: edge.VName,
+			}
+			if !searchCond.ShouldStop(edge, true) && !visited.Exist(child) {
+				visited.Add(child)
+				via[child] = edge
+				frontier = append(frontier, child)
+			}
+		}
+		if len(frontier) > 0 {
+			farthest = frontier
+		}
+	}
+
+	if grant != nil {
+		return &domain.Explanation{
+			Granted: true,
+			Paths:   [][]domain.Edge{grant},
+		}, nil
+	}
+	paths := [][]domain.Edge{}
+	if len(near) > 0 {
+		for _, edge := range near {
+			paths = append(paths, pathTo(edge))
+		}
+	} else {
+		for _, v := range farthest {
+			paths = append(paths, pathTo(via[v]))
+		}
+	}
+	return &domain.Explanation{Granted: false, Paths: paths}, nil
+}
+
+func (g *GraphInfra) BulkCheck(c context.Context, checks []domain.CheckRequest,
+	searchCond domain.SearchCond) ([]bool, error) {
+	results := make([]bool, len(checks))
+	sbjs := []domain.Vertex{}
+	bySbj := map[domain.Vertex][]int{}
+	for i, check := range checks {
+		if _, ok := bySbj[check.Sbj]; !ok {
+			sbjs = append(sbjs, check.Sbj)
+		}
+		bySbj[check.Sbj] = append(bySbj[check.Sbj], i)
+	}
+
+	for _, sbj := range sbjs {
+		granted, denied, err := g.walk(c, sbj, searchCond)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range bySbj[sbj] {
+			results[i], err = g.Evaluate(c, domain.Permission{
+				Rel:  checks[i].Rel,
+				Ns:   checks[i].Obj.Ns,
+				Name: checks[i].Obj.Name,
+			}, granted, denied)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// FindCycles scans every stored edge and returns the cycles found, each one as
+// the vertices along the loop with the first vertex repeated at the end.
+func (g *GraphInfra) FindCycles(c context.Context) ([][]domain.Vertex, error) {
+	edges, err := g.dbRepo.Get(c, domain.Edge{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	vertices := []domain.Vertex{}
+	adj := map[domain.Vertex][]domain.Vertex{}
+	for _, edge := range edges {
+		u := domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+		v := domain.Vertex{Ns: edge.VNs, Name: edge.VName}
+		if _, ok := adj[u]; !ok {
+			vertices = append(vertices, u)
+		}
+		adj[u] = append(adj[u], v)
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[domain.Vertex]int{}
+	path := []domain.Vertex{}
+	cycles := [][]domain.Vertex{}
+	var visit func(u domain.Vertex)
+	visit = func(u domain.Vertex) {
+		state[u] = visiting
+		path = append(path, u)
+		for _, v := range adj[u] {
+			switch state[v] {
+			case unvisited:
+				visit(v)
+			case visiting:
+				start := len(path) - 1
+				for path[start] != v {
+					start--
 				}
+				cycle := make([]domain.Vertex, 0, len(path)-start+1)
+				cycle = append(cycle, path[start:]...)
+				cycles = append(cycles, append(cycle, v))
 			}
 		}
+		path = path[:len(path)-1]
+		state[u] = done
+	}
+	for _, u := range vertices {
+		if state[u] == unvisited {
+			visit(u)
+		}
 	}
 
-	return false, nil
+	return cycles, nil
 }
 
 func (g *GraphInfra) SearchPermissions(c context.Context, start domain.Vertex,
 	isU bool, searchCond domain.SearchCond, collectCond domain.CollectCond,
 	maxDepth int) ([]domain.Permission, error) {
 	if isU {
-		depth := 0
 		pSet := set.NewSet[domain.Permission]()
+		dSet := set.NewSet[domain.Permission]()
 		visited := set.NewSet[domain.Vertex]()
-		q := queue.NewQueue[domain.Vertex]()
 		visited.Add(start)
-		q.Push(start)
-		for !q.IsEmpty() {
-			qLen := q.Len()
-			for i := 0; i < qLen; i++ {
-				vertex, _ := q.Pop()
-				query := domain.Edge{
-					UNs:   vertex.Ns,
-					UName: vertex.Name,
+		frontier := []domain.Vertex{start}
+		for depth := 1; len(frontier) > 0; depth++ {
+			qEdges, err := g.expand(c, frontier, true)
+			if err != nil {
+				return nil, err
+			}
+
+			frontier = []domain.Vertex{}
+			for _, edge := range qEdges {
+				if rel, deny := domain.DeniedRel(edge.Rel); deny {
+					dSet.Add(domain.Permission{
+						Ns:   edge.VNs,
+						Name: edge.VName,
+						Rel:  rel,
+					})
+					continue
 				}
-				qEdges, err := g.dbRepo.Get(c, query, true)
-				if err != nil {
-					return nil, err
+				child := domain.Vertex{
+					Ns:   edge.VNs,
+					Name: edge.VName,
 				}
-
-				for _, edge := range qEdges {
-					child := domain.Vertex{
+				if collectCond.ShouldCollect(edge, true) {
+					pSet.Add(domain.Permission{
 						Ns:   edge.VNs,
 						Name: edge.VName,
-					}
-					if collectCond.ShouldCollect(child) {
-						pSet.Add(domain.Permission{
-							Ns:   edge.VNs,
-							Name: edge.VName,
-							Rel:  edge.Rel,
-						})
-					}
-					if !searchCond.ShouldStop(child) &&
-						!visited.Exist(child) {
-						visited.Add(child)
-						q.Push(child)
-					}
+						Rel:  edge.Rel,
+					})
+				}
+				if !searchCond.ShouldStop(edge, true) &&
+					!visited.Exist(child) {
+					visited.Add(child)
+					frontier = append(frontier, child)
 				}
 			}
-			depth++
 			if depth >= maxDepth {
 				break
 			}
 		}
 
-		return pSet.ToSlice(), nil
+		// denies override grants
+		pers := []domain.Permission{}
+		for _, p := range pSet.ToSlice() {
+			if !dSet.Exist(p) {
+				pers = append(pers, p)
+			}
+		}
+		return pers, nil
 	} else {
-		depth := 0
 		pSet := set.NewSet[domain.Permission]()
 		visited := set.NewSet[domain.Vertex]()
-		q := queue.NewQueue[domain.Vertex]()
 		visited.Add(start)
-		q.Push(start)
-		for !q.IsEmpty() {
-			qLen := q.Len()
-			for i := 0; i < qLen; i++ {
-				vertex, _ := q.Pop()
-				query := domain.Edge{
-					VNs:   vertex.Ns,
-					VName: vertex.Name,
+		frontier := []domain.Vertex{start}
+		for depth := 1; len(frontier) > 0; depth++ {
+			qEdges, err := g.expand(c, frontier, false)
+			if err != nil {
+				return nil, err
+			}
+
+			frontier = []domain.Vertex{}
+			for _, edge := range qEdges {
+				if _, deny := domain.DeniedRel(edge.Rel); deny {
+					continue
 				}
-				qEdges, err := g.dbRepo.Get(c, query, true)
-				if err != nil {
-					return nil, err
+				parent := domain.Vertex{
+					Ns:   edge.UNs,
+					Name: edge.UName,
 				}
-
-				for _, edge := range qEdges {
-					parent := domain.Vertex{
+				if collectCond.ShouldCollect(edge, false) {
+					pSet.Add(domain.Permission{
 						Ns:   edge.UNs,
 						Name: edge.UName,
-					}
-					if collectCond.ShouldCollect(parent) {
-						pSet.Add(domain.Permission{
-							Ns:   edge.UNs,
-							Name: edge.UName,
-							Rel:  edge.Rel,
-						})
-					}
-					if !searchCond.ShouldStop(parent) &&
-						!visited.Exist(parent) {
-						visited.Add(parent)
-						q.Push(parent)
-					}
+						Rel:  edge.Rel,
+					})
+				}
+				if !searchCond.ShouldStop(edge, false) &&
+					!visited.Exist(parent) {
+					visited.Add(parent)
+					frontier = append(frontier, parent)
 				}
 			}
-			depth++
 			if depth >= maxDepth {
 				break
 			}
@@ -154,94 +509,47 @@ func (g *GraphInfra) SearchPermissions(c context.Context, start domain.Vertex,
 	}
 }
 
+// SearchVertices walks from start forward when isU is set, or else backward,
+// and collects the vertices reached by the edges matching collectCond.
 func (g *GraphInfra) SearchVertices(c context.Context, start domain.Vertex,
 	isU bool, searchCond domain.SearchCond, collectCond domain.CollectCond,
 	maxDepth int) ([]domain.Vertex, error) {
-	if isU {
-		depth := 0
-		vSet := set.NewSet[domain.Vertex]()
-		visited := set.NewSet[domain.Vertex]()
-		q := queue.NewQueue[domain.Vertex]()
-		visited.Add(start)
-		q.Push(start)
-		for !q.IsEmpty() {
-			qLen := q.Len()
-			for i := 0; i < qLen; i++ {
-				vertex, _ := q.Pop()
-				query := domain.Edge{
-					UNs:   vertex.Ns,
-					UName: vertex.Name,
-				}
-				qEdges, err := g.dbRepo.Get(c, query, true)
-				if err != nil {
-					return nil, err
-				}
-
-				for _, edge := range qEdges {
-					child := domain.Vertex{
-						Ns:   edge.VNs,
-						Name: edge.VName,
-					}
-					if collectCond.ShouldCollect(child) {
-						vSet.Add(child)
-					}
-					if !searchCond.ShouldStop(child) &&
-						!visited.Exist(child) {
-						visited.Add(child)
-						q.Push(child)
-					}
-				}
-			}
-			depth++
-			if depth >= maxDepth {
-				break
-			}
+	vSet := set.NewSet[domain.Vertex]()
+	visited := set.NewSet[domain.Vertex]()
+	visited.Add(start)
+	frontier := []domain.Vertex{start}
+	for depth := 1; len(frontier) > 0; depth++ {
+		qEdges, err := g.expand(c, frontier, isU)
+		if err != nil {
+			return nil, err
 		}
 
-		return vSet.ToSlice(), nil
-	} else {
-		depth := 0
-		vSet := set.NewSet[domain.Vertex]()
-		visited := set.NewSet[domain.Vertex]()
-		q := queue.NewQueue[domain.Vertex]()
-		visited.Add(start)
-		q.Push(start)
-		for !q.IsEmpty() {
-			qLen := q.Len()
-			for i := 0; i < qLen; i++ {
-				vertex, _ := q.Pop()
-				query := domain.Edge{
-					VNs:   vertex.Ns,
-					VName: vertex.Name,
-				}
-				qEdges, err := g.dbRepo.Get(c, query, true)
-				if err != nil {
-					return nil, err
-				}
-
-				for _, edge := range qEdges {
-					parent := domain.Vertex{
-						Ns:   edge.UNs,
-						Name: edge.UName,
-					}
-					if collectCond.ShouldCollect(parent) {
-						vSet.Add(parent)
-					}
-					if !searchCond.ShouldStop(parent) &&
-						!visited.Exist(parent) {
-						visited.Add(parent)
-						q.Push(parent)
-					}
+		frontier = []domain.Vertex{}
+		for _, edge := range qEdges {
+			next := domain.Vertex{
+				Ns:   edge.UNs,
+				Name: edge.UName,
+			}
+			if isU {
+				next = domain.Vertex{
+					Ns:   edge.VNs,
+					Name: edge.VName,
 				}
 			}
-			depth++
-			if depth >= maxDepth {
-				break
+			if collectCond.ShouldCollect(edge, isU) {
+				vSet.Add(next)
+			}
+			if !searchCond.ShouldStop(edge, isU) && !visited.Exist(next) {
+				visited.Add(next)
+				frontier = append(frontier, next)
 			}
 		}
-
-		return vSet.ToSlice(), nil
+		if depth >= maxDepth {
+			break
+		}
 	}
+
+	return vSet.ToSlice(), nil
 }
 
 // func (g *GraphInfra) GetPassedVertices(c context.Context, start domain.Vertex,
@@ -346,12 +654,12 @@ func (g *GraphInfra) SearchVertices(c context.Context, start domain.Vertex,
 
 func (g *GraphInfra) GetTree(c context.Context, start domain.Vertex, maxDepth int) (
 	*domain.TreeNode, error) {
-	if res, err := g.dbRepo.Get(
+	if res, err := g.getActive(
 		c,
 		domain.Edge{
 			UNs:   start.Ns,
 			UName: start.Name,
-		}, true); err != nil {
+		}); err != nil {
 		return nil, err
 	} else if len(res) == 0 {
 		return nil, domain.ErrRecordNotFound
@@ -364,45 +672,66 @@ func (g *GraphInfra) GetTree(c context.Context, start domain.Vertex, maxDepth in
 	}
 	visited := map[domain.Vertex]*domain.TreeNode{}
 	visited[start] = root
-	q := queue.NewQueue[*domain.TreeNode]()
-	q.Push(root)
-	for depth := 1; depth <= maxDepth && !q.IsEmpty(); depth++ {
-		for i := 0; i < q.Len(); i++ {
-			u, err := q.Pop()
-			if err != nil {
-				return nil, err
-			}
-			edges, err := g.dbRepo.Get(c,
-				domain.Edge{
-					UNs:   u.Ns,
-					UName: u.Name,
-				},
-				true,
-			)
-			if err != nil {
-				return nil, err
+	frontier := []domain.Vertex{start}
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		edges, err := g.expand(c, frontier, true)
+		if err != nil {
+			return nil, err
+		}
+		frontier = []domain.Vertex{}
+		for _, edge := range edges {
+			u := visited[domain.Vertex{Ns: edge.UNs, Name: edge.UName}]
+			v := domain.Vertex{
+				Ns:   edge.VNs,
+				Name: edge.VName,
 			}
-			for _, edge := range edges {
-				v := domain.Vertex{
-					Ns:   edge.VNs,
-					Name: edge.VName,
-				}
-				if node, ok := visited[v]; !ok {
-					newNode := &domain.TreeNode{
-						Ns:       v.Ns,
-						Name:     v.Name,
-						Children: map[string]*domain.TreeNode{},
-					}
-					q.Push(newNode)
-					visited[v] = newNode
-					visited[domain.Vertex{
-						Ns: u.Ns, Name: u.Name}].Children[edge.Rel] = newNode
-				} else {
-					visited[domain.Vertex{
-						Ns: u.Ns, Name: u.Name}].Children[edge.Rel] = node
+			if node, ok := visited[v]; !ok {
+				newNode := &domain.TreeNode{
+					Ns:       v.Ns,
+					Name:     v.Name,
+					Children: map[string]*domain.TreeNode{},
 				}
+				frontier = append(frontier, v)
+				visited[v] = newNode
+				u.Children[edge.Rel] = newNode
+			} else {
+				u.Children[edge.Rel] = node
 			}
 		}
 	}
 	return root, nil
 }
+
+// expand returns the edges in effect leaving the frontier when isSbj is set,
+// or else reaching it, with one query for the whole frontier.
+func (g *GraphInfra) expand(c context.Context, frontier []domain.Vertex,
+	isSbj bool) ([]domain.Edge, error) {
+	edges, err := g.dbRepo.GetNeighbors(c, frontier, isSbj)
+	if err != nil {
+		return nil, err
+	}
+	return active(edges), nil
+}
+
+// getActive queries the repository in query mode and drops the edges which
+// are not in effect at the moment.
+func (g *GraphInfra) getActive(c context.Context, filter domain.Edge) (
+	[]domain.Edge, error) {
+	edges, err := g.dbRepo.Get(c, filter, true)
+	if err != nil {
+		return nil, err
+	}
+	return active(edges), nil
+}
+
+// active drops the edges which are not in effect at the moment.
+func active(edges []domain.Edge) []domain.Edge {
+	now := time.Now()
+	res := edges[:0]
+	for _, edge := range edges {
+		if edge.Active(now) {
+			res = append(res, edge)
+		}
+	}
+	return res
+}
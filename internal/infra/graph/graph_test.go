@@ -0,0 +1,336 @@
+package graph_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph/graphtest"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGraph(t *testing.T, edges ...domain.Edge) *graph.GraphInfra {
+	return newGraphWithSchema(t, domain.Schema{}, edges...)
+}
+
+func newGraphWithSchema(t *testing.T, s domain.Schema,
+	edges ...domain.Edge) *graph.GraphInfra {
+	repo := memory.NewMemoryRepository()
+	for _, edge := range edges {
+		assert.NoError(t, repo.Create(context.Background(), edge))
+	}
+	schemaInfra := schema.NewSchemaInfra()
+	assert.NoError(t, schemaInfra.SetSchema(context.Background(), s))
+	return graph.NewGraphInfra(repo, schemaInfra)
+}
+
+func inherit(parent, child string) domain.Edge {
+	return domain.Edge{UNs: "role", UName: parent, Rel: "parent", VNs: "role",
+		VName: child}
+}
+
+func TestFindCycles(t *testing.T) {
+	c := context.Background()
+	role := func(name string) domain.Vertex {
+		return domain.Vertex{Ns: "role", Name: name}
+	}
+
+	g := newGraph(t, inherit("a", "b"), inherit("b", "c"))
+	cycles, err := g.FindCycles(c)
+	assert.NoError(t, err)
+	assert.Empty(t, cycles)
+
+	g = newGraph(t, inherit("a", "b"), inherit("b", "c"), inherit("c", "a"))
+	cycles, err = g.FindCycles(c)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]domain.Vertex{
+		{role("a"), role("b"), role("c"), role("a")},
+	}, cycles)
+}
+
+func TestBulkCheck(t *testing.T) {
+	c := context.Background()
+	g := newGraph(t,
+		domain.Edge{UNs: "user", UName: "alice", Rel: "member", VNs: "role",
+			VName: "admin"},
+		inherit("admin", "viewer"),
+		domain.Edge{UNs: "role", UName: "viewer", Rel: "read", VNs: "file",
+			VName: "a"},
+		domain.Edge{UNs: "role", UName: "admin", Rel: "write", VNs: "file",
+			VName: "a"},
+	)
+	alice := domain.Vertex{Ns: "user", Name: "alice"}
+	bob := domain.Vertex{Ns: "user", Name: "bob"}
+	file := domain.Vertex{Ns: "file", Name: "a"}
+
+	results, err := g.BulkCheck(c, []domain.CheckRequest{
+		{Sbj: alice, Rel: "read", Obj: file},
+		{Sbj: alice, Rel: "delete", Obj: file},
+		{Sbj: bob, Rel: "read", Obj: file},
+		{Sbj: alice, Rel: "write", Obj: file},
+		{Sbj: alice, Rel: "read", Obj: file},
+	}, domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false, true, true}, results)
+}
+
+func TestExplain(t *testing.T) {
+	c := context.Background()
+	member := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "admin"}
+	read := domain.Edge{UNs: "role", UName: "viewer", Rel: "read", VNs: "file",
+		VName: "a"}
+	g := newGraph(t, member, inherit("admin", "viewer"), read)
+	alice := domain.Vertex{Ns: "user", Name: "alice"}
+	file := domain.Vertex{Ns: "file", Name: "a"}
+
+	explanation, err := g.Explain(c, alice, file, "read", domain.SearchCond{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Explanation{
+		Granted: true,
+		Paths:   [][]domain.Edge{{member, inherit("admin", "viewer"), read}},
+	}, explanation)
+
+	explanation, err = g.Explain(c, alice, file, "write", domain.SearchCond{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Explanation{
+		Granted: false,
+		Paths:   [][]domain.Edge{{member, inherit("admin", "viewer"), read}},
+	}, explanation)
+
+	explanation, err = g.Explain(c, alice, file, "read", domain.SearchCond{}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Explanation{
+		Granted: false,
+		Paths:   [][]domain.Edge{{member, inherit("admin", "viewer")}},
+	}, explanation)
+}
+
+func TestCheckSkipsInactiveEdges(t *testing.T) {
+	c := context.Background()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	alice := domain.Vertex{Ns: "user", Name: "alice"}
+	file := domain.Vertex{Ns: "file", Name: "a"}
+	read := domain.Edge{UNs: "role", UName: "viewer", Rel: "read", VNs: "file",
+		VName: "a"}
+
+	tests := []struct {
+		name   string
+		period domain.Period
+		want   bool
+	}{
+		{"unbounded", domain.Period{}, true},
+		{"in effect", domain.Period{NotBefore: &past, ExpiresAt: &future}, true},
+		{"expired", domain.Period{ExpiresAt: &past}, false},
+		{"not yet", domain.Period{NotBefore: &future}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGraph(t, domain.Edge{UNs: "user", UName: "alice",
+				Rel: "member", VNs: "role", VName: "viewer", Period: tt.period},
+				read)
+			ok, err := g.Check(c, alice, file, "read", domain.SearchCond{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestDenyOverridesGrant(t *testing.T) {
+	c := context.Background()
+	alice := domain.Vertex{Ns: "user", Name: "alice"}
+	file := domain.Vertex{Ns: "file", Name: "a"}
+	member := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "engineer"}
+	read := domain.Edge{UNs: "role", UName: "engineer", Rel: "read",
+		VNs: "file", VName: "a"}
+	write := domain.Edge{UNs: "role", UName: "engineer", Rel: "write",
+		VNs: "file", VName: "a"}
+	deny := domain.Edge{UNs: "user", UName: "alice", Rel: domain.DenyRel("read"),
+		VNs: "file", VName: "a"}
+	g := newGraph(t, member, read, write, deny)
+
+	ok, err := g.Check(c, alice, file, "read", domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = g.Check(c, alice, file, "write", domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	results, err := g.BulkCheck(c, []domain.CheckRequest{
+		{Sbj: alice, Rel: "read", Obj: file},
+		{Sbj: alice, Rel: "write", Obj: file},
+	}, domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, results)
+
+	pers, err := g.SearchPermissions(c, alice, true, domain.SearchCond{},
+		domain.CollectCond{In: domain.Compare{Nses: []string{"file"}}}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Permission{{Rel: "write", Ns: "file", Name: "a"}},
+		pers)
+
+	explanation, err := g.Explain(c, alice, file, "read", domain.SearchCond{}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Explanation{
+		Granted: false,
+		Paths:   [][]domain.Edge{{deny}},
+	}, explanation)
+}
+
+func TestCheckRewrites(t *testing.T) {
+	c := context.Background()
+	docSchema := domain.Schema{
+		Namespaces: []domain.NamespaceConfig{
+			{
+				Name: "folder",
+				Relations: []domain.RelationConfig{
+					{Name: "editor"},
+					{Name: "viewer", ComputedUsersets: []string{"editor"}},
+				},
+			},
+			{
+				Name: "doc",
+				Relations: []domain.RelationConfig{
+					{Name: "folder"},
+					{Name: "editor"},
+					{
+						Name:             "viewer",
+						ComputedUsersets: []string{"editor"},
+						TupleToUsersets: []domain.TupleToUserset{
+							{Tupleset: "folder", ComputedUserset: "viewer"},
+						},
+					},
+				},
+			},
+		},
+	}
+	g := newGraphWithSchema(t, docSchema,
+		domain.Edge{UNs: "user", UName: "alice", Rel: "editor", VNs: "doc",
+			VName: "readme"},
+		domain.Edge{UNs: "user", UName: "bob", Rel: "editor", VNs: "folder",
+			VName: "root"},
+		domain.Edge{UNs: "folder", UName: "root", Rel: "folder", VNs: "doc",
+			VName: "readme"},
+		domain.Edge{UNs: "user", UName: "carol", Rel: domain.DenyRel("viewer"),
+			VNs: "doc", VName: "readme"},
+		domain.Edge{UNs: "user", UName: "carol", Rel: "editor", VNs: "doc",
+			VName: "readme"},
+	)
+	readme := domain.Vertex{Ns: "doc", Name: "readme"}
+
+	tests := []struct {
+		user string
+		rel  string
+		want bool
+	}{
+		{"alice", "editor", true},
+		{"alice", "viewer", true},
+		{"bob", "editor", false},
+		{"bob", "viewer", true},
+		{"carol", "editor", true},
+		{"carol", "viewer", false},
+		{"dave", "viewer", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.user+" "+tt.rel, func(t *testing.T) {
+			ok, err := g.Check(c, domain.Vertex{Ns: "user", Name: tt.user},
+				readme, tt.rel, domain.SearchCond{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestCheckSearchesFromBothEnds(t *testing.T) {
+	c := context.Background()
+	repo := memory.NewMemoryRepository()
+	// alice is in many roles inheriting chains of roles, the file is only
+	// read at the end of one chain
+	edges := []domain.Edge{
+		{UNs: "role", UName: "r7.3", Rel: "read", VNs: "file", VName: "a"},
+	}
+	for i := 0; i < 50; i++ {
+		role := fmt.Sprintf("r%d", i)
+		edges = append(edges, domain.Edge{UNs: "user", UName: "alice",
+			Rel: "member", VNs: "role", VName: role})
+		for j := 1; j <= 3; j++ {
+			child := fmt.Sprintf("r%d.%d", i, j)
+			edges = append(edges, inherit(role, child))
+			role = child
+		}
+	}
+	for _, edge := range edges {
+		assert.NoError(t, repo.Create(c, edge))
+	}
+	r := &remote{DbRepository: repo}
+	g := graph.NewGraphInfra(r, schema.NewSchemaInfra())
+	alice := domain.Vertex{Ns: "user", Name: "alice"}
+	file := domain.Vertex{Ns: "file", Name: "a"}
+
+	ok, err := g.Check(c, alice, file, "read", domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	checked := r.fetched
+
+	r.fetched = 0
+	oks, err := g.BulkCheck(c, []domain.CheckRequest{
+		{Sbj: alice, Rel: "read", Obj: file},
+	}, domain.SearchCond{})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true}, oks)
+	// the walk fetches every role of alice, the search from both ends only
+	// her memberships and the chain of the file
+	assert.Equal(t, 201, r.fetched)
+	assert.Equal(t, 54, checked)
+}
+
+// TestCheckMatchesWalk compares the bidirectional Check with the forward
+// walk of BulkCheck on random graphs.
+func TestCheckMatchesWalk(t *testing.T) {
+	c := context.Background()
+	rnd := rand.New(rand.NewSource(1))
+	vertex := func() domain.Vertex {
+		nses := []string{"user", "role", "file"}
+		return domain.Vertex{Ns: nses[rnd.Intn(len(nses))],
+			Name: fmt.Sprint(rnd.Intn(5))}
+	}
+	rels := []string{"member", "parent", "read", domain.DenyRel("read")}
+	for round := 0; round < 20; round++ {
+		repo := memory.NewMemoryRepository()
+		for i := 0; i < 40; i++ {
+			u, v := vertex(), vertex()
+			_ = repo.Create(c, domain.Edge{UNs: u.Ns, UName: u.Name,
+				Rel: rels[rnd.Intn(len(rels))], VNs: v.Ns, VName: v.Name})
+		}
+		g := graph.NewGraphInfra(repo, schema.NewSchemaInfra())
+		checks := []domain.CheckRequest{}
+		for i := 0; i < 30; i++ {
+			checks = append(checks, domain.CheckRequest{Sbj: vertex(),
+				Rel: rels[rnd.Intn(3)], Obj: vertex()})
+		}
+		want, err := g.BulkCheck(c, checks, domain.SearchCond{})
+		assert.NoError(t, err)
+		for i, check := range checks {
+			ok, err := g.Check(c, check.Sbj, check.Obj, check.Rel,
+				domain.SearchCond{})
+			assert.NoError(t, err)
+			assert.Equal(t, want[i], ok, "round %d: %v", round, check)
+		}
+	}
+}
+
+func TestConformance(t *testing.T) {
+	graphtest.Run(t, func(t *testing.T, schemaInfra domain.SchemaInfra,
+		edges []domain.Edge) domain.GraphInfra {
+		return graph.NewGraphInfra(graphtest.NewMemoryRepository(t, edges),
+			schemaInfra)
+	})
+}
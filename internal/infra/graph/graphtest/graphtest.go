@@ -0,0 +1,329 @@
+// Package graphtest checks that an implementation of domain.GraphInfra
+// answers like the BFS of package graph.
+package graphtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Constructor returns the GraphInfra under test over a store holding the
+// edges, each in its tenant, and nothing else. The GraphInfra is only used
+// until the next call.
+type Constructor func(t *testing.T, schemaInfra domain.SchemaInfra,
+	edges []domain.Edge) domain.GraphInfra
+
+// NewMemoryRepository returns a memory repository holding the edges, each in
+// its tenant.
+func NewMemoryRepository(t *testing.T,
+	edges []domain.Edge) *memory.MemoryRepository {
+	repo := memory.NewMemoryRepository()
+	for _, edge := range edges {
+		assert.NoError(t, repo.Create(
+			domain.WithTenant(context.Background(), edge.Tenant), edge))
+	}
+	return repo
+}
+
+// Run compares the checks and searches of the GraphInfra newGraph returns
+// with the ones of the BFS over a memory repository holding the same edges,
+// on a few hand written graphs and on random ones. Explain is compared on
+// whether it grants, the paths it picks may differ.
+func Run(t *testing.T, newGraph Constructor) {
+	t.Run("hierarchy", func(t *testing.T) {
+		g := compare(t, newGraph, domain.Schema{}, hierarchy())
+		c := context.Background()
+		// the edges hold the expected answers and not only identical ones
+		for _, tt := range []struct {
+			c    context.Context
+			user string
+			rel  string
+			want bool
+		}{
+			{c, "alice", "read", true},
+			{c, "alice", "write", true},
+			{c, "bob", "read", false},
+			{c, "bob", "write", true},
+			{c, "carol", "read", false},
+			{c, "dave", "read", false},
+			{domain.WithTenant(c, "acme"), "alice", "read", false},
+			{domain.WithTenant(c, "acme"), "erin", "read", true},
+		} {
+			ok, err := g.Check(tt.c, vertex("user", tt.user),
+				vertex("file", "a"), tt.rel, domain.SearchCond{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ok, "%s %s", tt.user, tt.rel)
+		}
+	})
+	t.Run("rewrites", func(t *testing.T) {
+		g := compare(t, newGraph, docSchema(), docs())
+		ok, err := g.Check(context.Background(), vertex("user", "bob"),
+			vertex("doc", "readme"), "viewer", domain.SearchCond{})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+	rnd := rand.New(rand.NewSource(1))
+	for round := 0; round < 5; round++ {
+		t.Run(fmt.Sprintf("random %d", round), func(t *testing.T) {
+			compare(t, newGraph, domain.Schema{}, random(rnd))
+		})
+	}
+}
+
+// compare runs every traversal from and to every vertex of the edges on both
+// GraphInfra and returns the one under test.
+func compare(t *testing.T, newGraph Constructor, s domain.Schema,
+	edges []domain.Edge) domain.GraphInfra {
+	schemaInfra := schema.NewSchemaInfra()
+	for _, edge := range edges {
+		assert.NoError(t, schemaInfra.SetSchema(
+			domain.WithTenant(context.Background(), edge.Tenant), s))
+	}
+	want := graph.NewGraphInfra(NewMemoryRepository(t, edges), schemaInfra)
+	got := newGraph(t, schemaInfra, edges)
+
+	tenants := map[string][]domain.Vertex{}
+	rels := []string{}
+	seen := map[any]bool{}
+	for _, edge := range edges {
+		for _, v := range []domain.Vertex{
+			{Ns: edge.UNs, Name: edge.UName},
+			{Ns: edge.VNs, Name: edge.VName},
+		} {
+			if key := [2]any{edge.Tenant, v}; !seen[key] {
+				seen[key] = true
+				tenants[edge.Tenant] = append(tenants[edge.Tenant], v)
+			}
+		}
+		if rel, _ := domain.DeniedRel(edge.Rel); !seen[rel] {
+			seen[rel] = true
+			rels = append(rels, rel)
+		}
+	}
+
+	for tenant, vertices := range tenants {
+		c := domain.WithTenant(context.Background(), tenant)
+		checks := []domain.CheckRequest{}
+		for _, sbj := range vertices {
+			for _, obj := range vertices {
+				for _, rel := range rels {
+					checks = append(checks,
+						domain.CheckRequest{Sbj: sbj, Rel: rel, Obj: obj})
+				}
+			}
+		}
+		for _, cond := range conds {
+			compareChecks(t, c, want, got, checks, cond.search)
+			for _, start := range vertices {
+				for _, isSbj := range []bool{true, false} {
+					for _, depth := range []int{1, 2, 3, math.MaxInt} {
+						compareSearches(t, c, want, got, start, isSbj,
+							cond.search, cond.collect, depth)
+					}
+				}
+			}
+		}
+	}
+	return got
+}
+
+var conds = []struct {
+	search  domain.SearchCond
+	collect domain.CollectCond
+}{
+	{},
+	{
+		search: domain.SearchCond{In: domain.Compare{
+			Rels: []string{"member", "parent"}}},
+		collect: domain.CollectCond{In: domain.Compare{
+			Nses: []string{"file", "doc"}}},
+	},
+	{
+		search: domain.SearchCond{In: domain.Compare{
+			Nses: []string{"role", "user"}}},
+		collect: domain.CollectCond{NotIn: domain.Compare{
+			Rels: []string{"read"}}},
+	},
+	{
+		search: domain.SearchCond{In: domain.Compare{
+			Nses: []string{"role"}, Rels: []string{"member", "parent"}}},
+		collect: domain.CollectCond{In: domain.Compare{
+			Nses: []string{"file"}, Rels: []string{"write"}}},
+	},
+	{
+		search: domain.SearchCond{In: domain.Compare{
+			Nses: []string{"role"}, Rels: []string{"member", "parent"},
+			All: true}},
+		collect: domain.CollectCond{NotIn: domain.Compare{
+			Nses: []string{"file"}, Rels: []string{"read"}, All: true}},
+	},
+}
+
+func compareChecks(t *testing.T, c context.Context, want domain.GraphInfra,
+	got domain.GraphInfra, checks []domain.CheckRequest,
+	searchCond domain.SearchCond) {
+	wantBulk, err := want.BulkCheck(c, checks, searchCond)
+	assert.NoError(t, err)
+	gotBulk, err := got.BulkCheck(c, checks, searchCond)
+	assert.NoError(t, err)
+	assert.Equal(t, wantBulk, gotBulk, "bulk check")
+
+	for _, check := range checks {
+		wantOk, err := want.Check(c, check.Sbj, check.Obj, check.Rel,
+			searchCond)
+		assert.NoError(t, err)
+		gotOk, err := got.Check(c, check.Sbj, check.Obj, check.Rel, searchCond)
+		assert.NoError(t, err)
+		assert.Equal(t, wantOk, gotOk, "check %v %v", check, searchCond)
+
+		wantExp, err := want.Explain(c, check.Sbj, check.Obj, check.Rel,
+			searchCond, math.MaxInt)
+		assert.NoError(t, err)
+		gotExp, err := got.Explain(c, check.Sbj, check.Obj, check.Rel,
+			searchCond, math.MaxInt)
+		assert.NoError(t, err)
+		if wantExp != nil && gotExp != nil {
+			assert.Equal(t, wantExp.Granted, gotExp.Granted, "explain %v %v",
+				check, searchCond)
+		}
+	}
+}
+
+func compareSearches(t *testing.T, c context.Context, want domain.GraphInfra,
+	got domain.GraphInfra, start domain.Vertex, isSbj bool,
+	searchCond domain.SearchCond, collectCond domain.CollectCond,
+	maxDepth int) {
+	msg := fmt.Sprintf("from %v forward %v depth %d %v %v", start, isSbj,
+		maxDepth, searchCond, collectCond)
+
+	wantPers, err := want.SearchPermissions(c, start, isSbj, searchCond,
+		collectCond, maxDepth)
+	assert.NoError(t, err)
+	gotPers, err := got.SearchPermissions(c, start, isSbj, searchCond,
+		collectCond, maxDepth)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, wantPers, gotPers, "permissions "+msg)
+
+	wantVers, err := want.SearchVertices(c, start, isSbj, searchCond,
+		collectCond, maxDepth)
+	assert.NoError(t, err)
+	gotVers, err := got.SearchVertices(c, start, isSbj, searchCond,
+		collectCond, maxDepth)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, wantVers, gotVers, "vertices "+msg)
+}
+
+func vertex(ns string, name string) domain.Vertex {
+	return domain.Vertex{Ns: ns, Name: name}
+}
+
+func edge(u domain.Vertex, rel string, v domain.Vertex) domain.Edge {
+	return domain.Edge{UNs: u.Ns, UName: u.Name, Rel: rel, VNs: v.Ns,
+		VName: v.Name}
+}
+
+// hierarchy is a role hierarchy with a cycle, a deny, edges out of effect
+// and a second tenant.
+func hierarchy() []domain.Edge {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	admin, viewer, auditor := vertex("role", "admin"), vertex("role", "viewer"),
+		vertex("role", "auditor")
+	file := vertex("file", "a")
+
+	expired := edge(vertex("user", "carol"), "member", viewer)
+	expired.ExpiresAt = &past
+	pending := edge(vertex("user", "dave"), "member", viewer)
+	pending.NotBefore = &future
+	other := edge(vertex("user", "erin"), "read", file)
+	other.Tenant = "acme"
+	return []domain.Edge{
+		edge(vertex("user", "alice"), "member", admin),
+		edge(vertex("user", "bob"), "member", admin),
+		edge(admin, "parent", viewer),
+		edge(viewer, "parent", auditor),
+		edge(auditor, "parent", admin),
+		edge(viewer, "read", file),
+		edge(admin, "write", file),
+		edge(vertex("user", "bob"), domain.DenyRel("read"), file),
+		expired,
+		pending,
+		other,
+	}
+}
+
+func docSchema() domain.Schema {
+	return domain.Schema{
+		Namespaces: []domain.NamespaceConfig{
+			{
+				Name: "folder",
+				Relations: []domain.RelationConfig{
+					{Name: "editor"},
+					{Name: "viewer", ComputedUsersets: []string{"editor"}},
+				},
+			},
+			{
+				Name: "doc",
+				Relations: []domain.RelationConfig{
+					{Name: "folder"},
+					{Name: "editor"},
+					{
+						Name:             "viewer",
+						ComputedUsersets: []string{"editor"},
+						TupleToUsersets: []domain.TupleToUserset{
+							{Tupleset: "folder", ComputedUserset: "viewer"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func docs() []domain.Edge {
+	readme, root := vertex("doc", "readme"), vertex("folder", "root")
+	return []domain.Edge{
+		edge(vertex("user", "alice"), "editor", readme),
+		edge(vertex("role", "staff"), "editor", root),
+		edge(vertex("user", "bob"), "member", vertex("role", "staff")),
+		edge(root, "folder", readme),
+		edge(vertex("user", "carol"), domain.DenyRel("viewer"), readme),
+		edge(vertex("user", "carol"), "editor", readme),
+	}
+}
+
+// random draws distinct edges between few vertices, so that the graph has
+// cycles, denies and long paths.
+func random(rnd *rand.Rand) []domain.Edge {
+	nses := []string{"user", "role", "file"}
+	rels := []string{"member", "parent", "read", domain.DenyRel("read")}
+	draw := func() domain.Vertex {
+		return vertex(nses[rnd.Intn(len(nses))], fmt.Sprint(rnd.Intn(4)))
+	}
+	past := time.Now().Add(-time.Hour)
+
+	edges := []domain.Edge{}
+	seen := map[domain.Edge]bool{}
+	for len(edges) < 25 {
+		e := edge(draw(), rels[rnd.Intn(len(rels))], draw())
+		if seen[e] {
+			continue
+		}
+		seen[e] = true
+		if rnd.Intn(10) == 0 {
+			e.ExpiresAt = &past
+		}
+		edges = append(edges, e)
+	}
+	return edges
+}
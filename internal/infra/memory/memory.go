@@ -0,0 +1,328 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/watch"
+)
+
+// watchBuffer is how many changes are retained for the watchers to resume.
+const watchBuffer = 10000
+
+// MemoryRepository keeps edges in process memory. It mirrors the behaviour of
+// mongo.MongoRepository and is meant for tests and embedded deployments.
+type MemoryRepository struct {
+	mu      sync.RWMutex
+	nextId  uint64
+	edges   map[uint64]domain.Edge
+	uIndex  map[domain.Vertex]map[uint64]struct{}
+	vIndex  map[domain.Vertex]map[uint64]struct{}
+	changes *watch.Broadcaster
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		edges:   map[uint64]domain.Edge{},
+		uIndex:  map[domain.Vertex]map[uint64]struct{}{},
+		vIndex:  map[domain.Vertex]map[uint64]struct{}{},
+		changes: watch.NewBroadcaster(watchBuffer),
+	}
+}
+
+func (r *MemoryRepository) Ping(c context.Context) error {
+	return c.Err()
+}
+
+func (r *MemoryRepository) Get(c context.Context, filter domain.Edge,
+	queryMode bool) ([]domain.Edge, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	filter.Tenant = domain.TenantFrom(c)
+	ids := r.find(filter, queryMode)
+	if !queryMode {
+		if len(ids) == 0 {
+			return nil, domain.ErrRecordNotFound
+		} else if len(ids) > 1 {
+			return nil, domain.ErrDuplicateRecord
+		}
+	}
+	edges := make([]domain.Edge, len(ids))
+	for i, id := range ids {
+		edges[i] = r.edges[id]
+	}
+	// the reads always observe every write, so they reach the last revision
+	domain.RevisionFrom(c).Reach(r.changes.Token())
+	return edges, nil
+}
+
+// GetNeighbors returns the edges grouped by vertex, in the order of the
+// vertices.
+func (r *MemoryRepository) GetNeighbors(c context.Context,
+	vertices []domain.Vertex, isSbj bool) ([]domain.Edge, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	edges := []domain.Edge{}
+	for _, vertex := range vertices {
+		filter := domain.Edge{Tenant: domain.TenantFrom(c)}
+		if isSbj {
+			filter.UNs, filter.UName = vertex.Ns, vertex.Name
+		} else {
+			filter.VNs, filter.VName = vertex.Ns, vertex.Name
+		}
+		for _, id := range r.find(filter, true) {
+			edges = append(edges, r.edges[id])
+		}
+	}
+	domain.RevisionFrom(c).Reach(r.changes.Token())
+	return edges, nil
+}
+
+func (r *MemoryRepository) Create(c context.Context, edge domain.Edge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	edge.Tenant = domain.TenantFrom(c)
+	if len(r.find(edge, false)) > 0 {
+		return domain.ErrDuplicateRecord
+	}
+	r.insert(edge)
+	r.publish(c, change(domain.CreateChange, edge))
+	return nil
+}
+
+func (r *MemoryRepository) Delete(c context.Context, edge domain.Edge,
+	queryMode bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	edge.Tenant = domain.TenantFrom(c)
+	ids := r.find(edge, queryMode)
+	if !queryMode {
+		if len(ids) == 0 {
+			return domain.ErrRecordNotFound
+		} else if len(ids) > 1 {
+			return domain.ErrDuplicateRecord
+		}
+	}
+	changes := make([]domain.Change, len(ids))
+	for i, id := range ids {
+		changes[i] = change(domain.DeleteChange, r.edges[id])
+		r.remove(id)
+	}
+	r.publish(c, changes...)
+	return nil
+}
+
+// ApplyOperations holds the write lock for the whole batch and rolls back the
+// operations already applied if a later one fails.
+func (r *MemoryRepository) ApplyOperations(c context.Context,
+	operations []domain.Operation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := map[uint64]struct{}{}
+	deleted := map[uint64]domain.Edge{}
+	changes := []domain.Change{}
+	rollback := func() {
+		for id := range created {
+			r.remove(id)
+		}
+		for id, edge := range deleted {
+			r.insertAt(id, edge)
+		}
+	}
+	tenant := domain.TenantFrom(c)
+	for _, op := range operations {
+		op.Edge.Tenant = tenant
+		switch op.Type {
+		case domain.CreateOperation:
+			if len(r.find(op.Edge, false)) > 0 {
+				rollback()
+				return domain.ErrDuplicateRecord
+			}
+			created[r.insert(op.Edge)] = struct{}{}
+			changes = append(changes, change(domain.CreateChange, op.Edge))
+		case domain.DeleteOperation:
+			ids := r.find(op.Edge, false)
+			if len(ids) != 1 {
+				rollback()
+				if len(ids) == 0 {
+					return domain.ErrRecordNotFound
+				}
+				return domain.ErrDuplicateRecord
+			}
+			if _, ok := created[ids[0]]; ok {
+				delete(created, ids[0])
+			} else {
+				deleted[ids[0]] = r.edges[ids[0]]
+			}
+			changes = append(changes,
+				change(domain.DeleteChange, r.edges[ids[0]]))
+			r.remove(ids[0])
+		case domain.CreateIfNotExistOperation:
+			if len(r.find(op.Edge, false)) == 0 {
+				created[r.insert(op.Edge)] = struct{}{}
+				changes = append(changes,
+					change(domain.CreateChange, op.Edge))
+			}
+		default:
+			rollback()
+			return domain.ErrBodyAttribute
+		}
+	}
+	r.publish(c, changes...)
+	return nil
+}
+
+func (r *MemoryRepository) DeleteExpired(c context.Context, now time.Time) (
+	int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes := []domain.Change{}
+	for _, id := range r.sortedIds() {
+		edge := r.edges[id]
+		if edge.ExpiresAt != nil && !edge.ExpiresAt.After(now) {
+			changes = append(changes, change(domain.DeleteChange, edge))
+			r.remove(id)
+		}
+	}
+	r.publish(c, changes...)
+	return int64(len(changes)), nil
+}
+
+func (r *MemoryRepository) ClearAll(c context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes := make([]domain.Change, 0, len(r.edges))
+	for _, id := range r.sortedIds() {
+		changes = append(changes, change(domain.DeleteChange, r.edges[id]))
+	}
+	r.publish(c, changes...)
+	r.edges = map[uint64]domain.Edge{}
+	r.uIndex = map[domain.Vertex]map[uint64]struct{}{}
+	r.vIndex = map[domain.Vertex]map[uint64]struct{}{}
+	return nil
+}
+
+func (r *MemoryRepository) Watch(c context.Context, token string,
+	send func(domain.Change) error) error {
+	return r.changes.Watch(c, token, send)
+}
+
+// publish hands the changes to the watchers, the revisions are their
+// tokens.
+func (r *MemoryRepository) publish(c context.Context,
+	changes ...domain.Change) {
+	r.changes.Publish(changes...)
+	domain.RevisionFrom(c).Reach(r.changes.Token())
+}
+
+func change(t domain.ChangeType, edge domain.Edge) domain.Change {
+	return domain.Change{Type: t, Edge: &edge}
+}
+
+func (r *MemoryRepository) sortedIds() []uint64 {
+	ids := make([]uint64, 0, len(r.edges))
+	for id := range r.edges {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (r *MemoryRepository) insert(edge domain.Edge) uint64 {
+	id := r.nextId
+	r.nextId++
+	r.insertAt(id, edge)
+	return id
+}
+
+func (r *MemoryRepository) insertAt(id uint64, edge domain.Edge) {
+	r.edges[id] = edge
+
+	u := domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+	if r.uIndex[u] == nil {
+		r.uIndex[u] = map[uint64]struct{}{}
+	}
+	r.uIndex[u][id] = struct{}{}
+
+	v := domain.Vertex{Ns: edge.VNs, Name: edge.VName}
+	if r.vIndex[v] == nil {
+		r.vIndex[v] = map[uint64]struct{}{}
+	}
+	r.vIndex[v][id] = struct{}{}
+}
+
+func (r *MemoryRepository) remove(id uint64) {
+	edge, ok := r.edges[id]
+	if !ok {
+		return
+	}
+	delete(r.edges, id)
+
+	u := domain.Vertex{Ns: edge.UNs, Name: edge.UName}
+	delete(r.uIndex[u], id)
+	if len(r.uIndex[u]) == 0 {
+		delete(r.uIndex, u)
+	}
+
+	v := domain.Vertex{Ns: edge.VNs, Name: edge.VName}
+	delete(r.vIndex[v], id)
+	if len(r.vIndex[v]) == 0 {
+		delete(r.vIndex, v)
+	}
+}
+
+// find returns the ids of the matching edges in insertion order. In query mode
+// zero valued fields of the filter are ignored, otherwise every field must
+// match exactly, the same as passing the whole struct as a mongo filter. The
+// tenant always has to match.
+func (r *MemoryRepository) find(filter domain.Edge, queryMode bool) []uint64 {
+	var candidates map[uint64]struct{}
+	u := domain.Vertex{Ns: filter.UNs, Name: filter.UName}
+	v := domain.Vertex{Ns: filter.VNs, Name: filter.VName}
+	switch {
+	case !queryMode || (u.Ns != "" && u.Name != ""):
+		candidates = r.uIndex[u]
+	case v.Ns != "" && v.Name != "":
+		candidates = r.vIndex[v]
+	default:
+		candidates = make(map[uint64]struct{}, len(r.edges))
+		for id := range r.edges {
+			candidates[id] = struct{}{}
+		}
+	}
+
+	ids := []uint64{}
+	for id := range candidates {
+		if match(r.edges[id], filter, queryMode) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func match(edge domain.Edge, filter domain.Edge, queryMode bool) bool {
+	if edge.Tenant != filter.Tenant {
+		return false
+	}
+	if !queryMode {
+		return edge.UNs == filter.UNs && edge.UName == filter.UName &&
+			edge.Rel == filter.Rel && edge.VNs == filter.VNs &&
+			edge.VName == filter.VName
+	}
+	return (filter.UNs == "" || edge.UNs == filter.UNs) &&
+		(filter.UName == "" || edge.UName == filter.UName) &&
+		(filter.Rel == "" || edge.Rel == filter.Rel) &&
+		(filter.VNs == "" || edge.VNs == filter.VNs) &&
+		(filter.VName == "" || edge.VName == filter.VName)
+}
@@ -0,0 +1,271 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	c := context.Background()
+	repo := memory.NewMemoryRepository()
+	member := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "admin"}
+	read := domain.Edge{UNs: "role", UName: "admin", Rel: "read",
+		VNs: "file", VName: "a"}
+	write := domain.Edge{UNs: "role", UName: "admin", Rel: "write",
+		VNs: "file", VName: "a"}
+	for _, e := range []domain.Edge{member, read, write} {
+		assert.NoError(t, repo.Create(c, e))
+	}
+
+	t.Run("query mode ignores zero values", func(t *testing.T) {
+		edges, err := repo.Get(c, domain.Edge{UNs: "role", UName: "admin"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Edge{read, write}, edges)
+
+		edges, err = repo.Get(c, domain.Edge{VNs: "file", VName: "a",
+			Rel: "write"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Edge{write}, edges)
+
+		edges, err = repo.Get(c, domain.Edge{Rel: "member"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Edge{member}, edges)
+
+		edges, err = repo.Get(c, domain.Edge{}, true)
+		assert.NoError(t, err)
+		assert.Len(t, edges, 3)
+	})
+
+	t.Run("exact mode", func(t *testing.T) {
+		edges, err := repo.Get(c, member, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Edge{member}, edges)
+
+		_, err = repo.Get(c, domain.Edge{UNs: "role", UName: "admin"}, false)
+		assert.ErrorIs(t, err, domain.ErrRecordNotFound)
+
+		assert.ErrorIs(t, repo.Create(c, member), domain.ErrDuplicateRecord)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert.NoError(t, repo.Delete(c, domain.Edge{UNs: "user",
+			UName: "alice"}, true))
+		assert.ErrorIs(t, repo.Delete(c, member, false),
+			domain.ErrRecordNotFound)
+
+		assert.NoError(t, repo.Delete(c, read, false))
+		edges, err := repo.Get(c, domain.Edge{VNs: "file", VName: "a"}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Edge{write}, edges)
+
+		assert.NoError(t, repo.ClearAll(c))
+		edges, err = repo.Get(c, domain.Edge{}, true)
+		assert.NoError(t, err)
+		assert.Empty(t, edges)
+	})
+}
+
+func TestMemoryRepositoryApplyOperations(t *testing.T) {
+	c := context.Background()
+	repo := memory.NewMemoryRepository()
+	viewer := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "viewer"}
+	editor := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "editor"}
+	assert.NoError(t, repo.Create(c, viewer))
+
+	err := repo.ApplyOperations(c, []domain.Operation{
+		{Type: domain.DeleteOperation, Edge: viewer},
+		{Type: domain.CreateOperation, Edge: editor},
+		{Type: domain.DeleteOperation, Edge: viewer},
+	})
+	assert.ErrorIs(t, err, domain.ErrRecordNotFound)
+	edges, err := repo.Get(c, domain.Edge{UNs: "user", UName: "alice"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Edge{viewer}, edges)
+
+	err = repo.ApplyOperations(c, []domain.Operation{
+		{Type: domain.CreateOperation, Edge: editor},
+		{Type: domain.CreateOperation, Edge: editor},
+	})
+	assert.ErrorIs(t, err, domain.ErrDuplicateRecord)
+
+	assert.NoError(t, repo.ApplyOperations(c, []domain.Operation{
+		{Type: domain.DeleteOperation, Edge: viewer},
+		{Type: domain.CreateOperation, Edge: editor},
+		{Type: domain.CreateIfNotExistOperation, Edge: editor},
+	}))
+	edges, err = repo.Get(c, domain.Edge{UNs: "user", UName: "alice"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Edge{editor}, edges)
+}
+
+func TestMemoryRepositoryDeleteExpired(t *testing.T) {
+	c := context.Background()
+	repo := memory.NewMemoryRepository()
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+	later := now.Add(time.Minute)
+	alive := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "admin", Period: domain.Period{ExpiresAt: &later}}
+	assert.NoError(t, repo.Create(c, alive))
+	assert.NoError(t, repo.Create(c, domain.Edge{UNs: "user", UName: "bob",
+		Rel: "member", VNs: "role", VName: "admin",
+		Period: domain.Period{ExpiresAt: &expired}}))
+	assert.NoError(t, repo.Create(c, domain.Edge{UNs: "user", UName: "carol",
+		Rel: "member", VNs: "role", VName: "admin"}))
+
+	deleted, err := repo.DeleteExpired(c, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	edges, err := repo.Get(c, domain.Edge{VNs: "role", VName: "admin"}, true)
+	assert.NoError(t, err)
+	assert.Len(t, edges, 2)
+	assert.Equal(t, alive, edges[0])
+}
+
+func TestMemoryRepositoryTenants(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	acme := domain.WithTenant(context.Background(), "acme")
+	globex := domain.WithTenant(context.Background(), "globex")
+	edge := domain.Edge{UNs: "user", UName: "alice", Rel: "read", VNs: "file",
+		VName: "a"}
+	assert.NoError(t, repo.Create(acme, edge))
+	// the same edge is another record in another tenant
+	assert.NoError(t, repo.Create(globex, edge))
+	// a tenant in the edge itself is overridden by the context
+	assert.NoError(t, repo.Create(acme, domain.Edge{Tenant: "globex",
+		UNs: "user", UName: "bob", Rel: "read", VNs: "file", VName: "a"}))
+
+	edges, err := repo.Get(globex, domain.Edge{}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Edge{{Tenant: "globex", UNs: "user",
+		UName: "alice", Rel: "read", VNs: "file", VName: "a"}}, edges)
+
+	assert.NoError(t, repo.Delete(acme, domain.Edge{VNs: "file"}, true))
+	edges, err = repo.Get(acme, domain.Edge{}, true)
+	assert.NoError(t, err)
+	assert.Empty(t, edges)
+	_, err = repo.Get(globex, edge, false)
+	assert.NoError(t, err)
+	_, err = repo.Get(context.Background(), edge, false)
+	assert.ErrorIs(t, err, domain.ErrRecordNotFound)
+}
+
+func TestMemoryRepositoryWatch(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	acme := domain.WithTenant(context.Background(), "acme")
+	globex := domain.WithTenant(context.Background(), "globex")
+	stop := errors.New("stop")
+	collect := func(c context.Context, token string, n int) (
+		[]domain.Change, error) {
+		changes := []domain.Change{}
+		err := repo.Watch(c, token, func(change domain.Change) error {
+			changes = append(changes, change)
+			if len(changes) == n {
+				return stop
+			}
+			return nil
+		})
+		return changes, err
+	}
+
+	changes, err := collect(acme, "", 1)
+	assert.ErrorIs(t, err, stop)
+	checkpoint := changes[0]
+	assert.Equal(t, domain.CheckpointChange, checkpoint.Type)
+
+	edge := domain.Edge{UNs: "user", UName: "alice", Rel: "read", VNs: "file",
+		VName: "a"}
+	assert.NoError(t, repo.Create(globex, edge))
+	assert.NoError(t, repo.Create(acme, edge))
+	assert.NoError(t, repo.Delete(acme, edge, false))
+
+	// the changes of globex are not seen by acme
+	changes, err = collect(acme, checkpoint.Token, 3)
+	assert.ErrorIs(t, err, stop)
+	edge.Tenant = "acme"
+	assert.Equal(t, domain.CheckpointChange, changes[0].Type)
+	assert.Equal(t, domain.CreateChange, changes[1].Type)
+	assert.Equal(t, &edge, changes[1].Edge)
+	assert.Equal(t, domain.DeleteChange, changes[2].Type)
+	assert.Equal(t, &edge, changes[2].Edge)
+
+	// resuming after the create only returns the delete
+	changes, err = collect(acme, changes[1].Token, 2)
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, domain.DeleteChange, changes[1].Type)
+
+	// an idle watch ends with its context
+	c, cancel := context.WithTimeout(acme, 10*time.Millisecond)
+	defer cancel()
+	_, err = collect(c, changes[1].Token, 2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = collect(acme, "12345", 1)
+	assert.ErrorIs(t, err, domain.ErrTokenExpired)
+	_, err = collect(acme, "x", 1)
+	assert.ErrorIs(t, err, domain.ErrBodyAttribute)
+}
+
+func TestMemoryRepositoryRevision(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	write, read := &domain.Revision{}, &domain.Revision{}
+	edge := domain.Edge{UNs: "user", UName: "alice", Rel: "read", VNs: "file",
+		VName: "a"}
+	assert.NoError(t, repo.Create(domain.WithRevision(context.Background(),
+		write), edge))
+	assert.NotEmpty(t, write.Reached())
+
+	read.AtLeast = write.Reached()
+	edges, err := repo.Get(domain.WithRevision(context.Background(), read),
+		edge, false)
+	assert.NoError(t, err)
+	assert.Len(t, edges, 1)
+	assert.Equal(t, write.Reached(), read.Reached())
+
+	// a failed write reaches nothing
+	failed := &domain.Revision{}
+	assert.ErrorIs(t, repo.Create(domain.WithRevision(context.Background(),
+		failed), edge), domain.ErrDuplicateRecord)
+	assert.Empty(t, failed.Reached())
+}
+
+func TestMemoryRepositoryGetNeighbors(t *testing.T) {
+	c := context.Background()
+	repo := memory.NewMemoryRepository()
+	alice := domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+		VNs: "role", VName: "admin"}
+	bob := domain.Edge{UNs: "user", UName: "bob", Rel: "member", VNs: "role",
+		VName: "viewer"}
+	read := domain.Edge{UNs: "role", UName: "admin", Rel: "read", VNs: "file",
+		VName: "a"}
+	for _, edge := range []domain.Edge{alice, bob, read} {
+		assert.NoError(t, repo.Create(c, edge))
+	}
+
+	edges, err := repo.GetNeighbors(c, []domain.Vertex{
+		{Ns: "role", Name: "admin"}, {Ns: "user", Name: "bob"},
+		{Ns: "user", Name: "nobody"},
+	}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Edge{read, bob}, edges)
+
+	edges, err = repo.GetNeighbors(c, []domain.Vertex{
+		{Ns: "role", Name: "viewer"}, {Ns: "role", Name: "admin"},
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Edge{bob, alice}, edges)
+
+	edges, err = repo.GetNeighbors(domain.WithTenant(c, "acme"),
+		[]domain.Vertex{{Ns: "role", Name: "admin"}}, true)
+	assert.NoError(t, err)
+	assert.Empty(t, edges)
+}
@@ -2,9 +2,12 @@ package mongo
 
 import (
 	"context"
-	"time"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
 
 	errors "github.com/rotisserie/eris"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -13,39 +16,177 @@ import (
 )
 
 func InitDb() (*mongo.Client, func(), error) {
-	ctx := context.Background()
+	timeout := viper.GetDuration("mongo.connect_timeout")
+	opts := options.Client().
+		ApplyURI(viper.GetString("mongo.uri")).
+		SetConnectTimeout(timeout)
+	if username := viper.GetString("mongo.username"); username != "" {
+		opts.SetAuth(options.Credential{
+			Username:   username,
+			Password:   viper.GetString("mongo.password"),
+			AuthSource: viper.GetString("mongo.auth_source"),
+		})
+	}
+	if viper.GetBool("mongo.tls.enabled") {
+		tlsConfig, err := newTLSConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
 
-	client, err := mongo.Connect(ctx, options.Client().
-		ApplyURI("mongodb://localhost:27017"))
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	err = client.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Unable to connect to MongoDB")
+	}
+
 	collection := client.Database(viper.GetString("mongo.db")).
 		Collection(viper.GetString("mongo.collection"))
-	_, err = collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+	if err := migrateTenants(ctx, collection); err != nil {
+		return nil, nil, err
+	}
+	if err := migrateVertices(ctx, collection); err != nil {
+		return nil, nil, err
+	}
+	_, err = collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "tenant", Value: 1},
+				{Key: "v_ns", Value: 1},
+				{Key: "v_name", Value: 1},
+			},
+			Options: options.Index().SetName("tenant_v_index"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant", Value: 1},
+				{Key: "u_ns", Value: 1},
+				{Key: "u_name", Value: 1},
+			},
+			Options: options.Index().SetName("tenant_u_index"),
+		},
 		{
-			Keys:    bson.D{{Key: "v_ns", Value: 1}, {Key: "v_name", Value: 1}},
-			Options: options.Index().SetName("v_index"),
+			Keys: bson.D{
+				{Key: "tenant", Value: 1},
+				{Key: "u_ns", Value: 1},
+				{Key: "u_name", Value: 1},
+				{Key: "rel", Value: 1},
+				{Key: "v_ns", Value: 1},
+				{Key: "v_name", Value: 1},
+			},
+			Options: options.Index().SetName("tenant_edge_index").
+				SetUnique(true),
 		},
 		{
-			Keys:    bson.D{{Key: "u_ns", Value: 1}, {Key: "u_name", Value: 1}},
-			Options: options.Index().SetName("u_index"),
+			Keys:    bson.D{{Key: "tenant", Value: 1}, {Key: "u", Value: 1}},
+			Options: options.Index().SetName("tenant_u_key_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "tenant", Value: 1}, {Key: "v", Value: 1}},
+			Options: options.Index().SetName("tenant_v_key_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_index").SetSparse(true),
 		},
 	})
 	if err != nil {
-		return nil, nil, err
+		// building the unique index fails while duplicated edges are stored
+		return nil, nil, errors.Wrap(err, "create indexes")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-	err = client.Ping(ctx, readpref.Primary())
+	// Watch reads the deleted edges from their pre-images
+	err = collection.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection.Name()},
+		{Key: "changeStreamPreAndPostImages",
+			Value: bson.M{"enabled": true}},
+	}).Err()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "Unable to connect to MongoDB")
+		log.Warn().Err(err).Msg("watch will not report the deleted edges")
 	}
 
 	var Disconnect = func() {
-		client.Disconnect(ctx)
+		client.Disconnect(context.Background())
 	}
 	return client, Disconnect, nil
 }
+
+// migrateTenants moves the edges stored before tenants existed to the default
+// tenant and drops the indexes which were not prefixed by the tenant.
+func migrateTenants(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"tenant": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"tenant": ""}})
+	if err != nil {
+		return errors.Wrap(err, "migrate tenants")
+	}
+
+	specs, err := collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return errors.Wrap(err, "list indexes")
+	}
+	for _, spec := range specs {
+		switch spec.Name {
+		case "u_index", "v_index", "edge_index":
+			if _, err := collection.Indexes().DropOne(ctx,
+				spec.Name); err != nil {
+				return errors.Wrapf(err, "drop index %s", spec.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// migrateVertices sets the u and v join keys of the edges stored before
+// GraphInfra needed them.
+func migrateVertices(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"u": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.D{
+			{Key: "u", Value: bson.D{
+				{Key: "ns", Value: "$u_ns"},
+				{Key: "name", Value: "$u_name"},
+			}},
+			{Key: "v", Value: bson.D{
+				{Key: "ns", Value: "$v_ns"},
+				{Key: "name", Value: "$v_name"},
+			}},
+		}}}})
+	if err != nil {
+		return errors.Wrap(err, "migrate vertices")
+	}
+	return nil
+}
+
+func newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: viper.GetBool("mongo.tls.insecure_skip_verify"),
+	}
+	if caFile := viper.GetString("mongo.tls.ca_file"); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read mongo.tls.ca_file")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("mongo.tls.ca_file: no certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if certFile := viper.GetString("mongo.tls.cert_file"); certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile,
+			viper.GetString("mongo.tls.key_file"))
+		if err != nil {
+			return nil, errors.Wrap(err, "load mongo client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
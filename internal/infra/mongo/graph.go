@@ -0,0 +1,279 @@
+package mongo
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/go-utility/set"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GraphInfra walks the graph inside MongoDB, each check or search is a single
+// aggregation of two $graphLookup stages instead of a query per BFS level.
+// It answers like graph.GraphInfra, which it embeds for the rewrite rules of
+// the schema and for Explain, GetTree and FindCycles. The aggregations start
+// from $documents, which requires MongoDB 5.1.
+type GraphInfra struct {
+	*graph.GraphInfra
+	repo *MongoRepository
+}
+
+func NewGraphInfra(repo *MongoRepository,
+	schemaInfra domain.SchemaInfra) *GraphInfra {
+	return &GraphInfra{
+		GraphInfra: graph.NewGraphInfra(repo, schemaInfra),
+		repo:       repo,
+	}
+}
+
+func (g *GraphInfra) Check(c context.Context, start domain.Vertex,
+	target domain.Vertex, relation string, searchCond domain.SearchCond) (
+	bool, error) {
+	granted, denied, err := g.walk(c, start, searchCond)
+	if err != nil {
+		return false, err
+	}
+	return g.Evaluate(c, domain.Permission{
+		Rel:  relation,
+		Ns:   target.Ns,
+		Name: target.Name,
+	}, granted, denied)
+}
+
+func (g *GraphInfra) BulkCheck(c context.Context,
+	checks []domain.CheckRequest, searchCond domain.SearchCond) ([]bool, error) {
+	results := make([]bool, len(checks))
+	sbjs := []domain.Vertex{}
+	bySbj := map[domain.Vertex][]int{}
+	for i, check := range checks {
+		if _, ok := bySbj[check.Sbj]; !ok {
+			sbjs = append(sbjs, check.Sbj)
+		}
+		bySbj[check.Sbj] = append(bySbj[check.Sbj], i)
+	}
+
+	for _, sbj := range sbjs {
+		granted, denied, err := g.walk(c, sbj, searchCond)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range bySbj[sbj] {
+			results[i], err = g.Evaluate(c, domain.Permission{
+				Rel:  checks[i].Rel,
+				Ns:   checks[i].Obj.Ns,
+				Name: checks[i].Obj.Name,
+			}, granted, denied)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// walk returns every permission reached from start through stored edges and
+// the ones revoked by deny edges on the way.
+func (g *GraphInfra) walk(c context.Context, start domain.Vertex,
+	searchCond domain.SearchCond) (granted set.Set[domain.Permission],
+	denied set.Set[domain.Permission], err error) {
+	granted = set.NewSet[domain.Permission]()
+	denied = set.NewSet[domain.Permission]()
+	edges, err := g.reach(c, start, true, false, searchCond, math.MaxInt)
+	if err != nil {
+		return granted, denied, err
+	}
+	for _, edge := range edges {
+		p := domain.Permission{
+			Rel:  edge.Rel,
+			Ns:   edge.VNs,
+			Name: edge.VName,
+		}
+		if rel, deny := domain.DeniedRel(edge.Rel); deny {
+			p.Rel = rel
+			denied.Add(p)
+			continue
+		}
+		granted.Add(p)
+	}
+	return granted, denied, nil
+}
+
+func (g *GraphInfra) SearchPermissions(c context.Context, start domain.Vertex,
+	isU bool, searchCond domain.SearchCond, collectCond domain.CollectCond,
+	maxDepth int) ([]domain.Permission, error) {
+	edges, err := g.reach(c, start, isU, false, searchCond, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	pSet := set.NewSet[domain.Permission]()
+	dSet := set.NewSet[domain.Permission]()
+	for _, edge := range edges {
+		if rel, deny := domain.DeniedRel(edge.Rel); deny {
+			if isU {
+				dSet.Add(domain.Permission{
+					Ns:   edge.VNs,
+					Name: edge.VName,
+					Rel:  rel,
+				})
+			}
+			continue
+		}
+		if !collectCond.ShouldCollect(edge, isU) {
+			continue
+		}
+		p := domain.Permission{Ns: edge.UNs, Name: edge.UName, Rel: edge.Rel}
+		if isU {
+			p = domain.Permission{Ns: edge.VNs, Name: edge.VName, Rel: edge.Rel}
+		}
+		pSet.Add(p)
+	}
+
+	// denies override grants
+	pers := []domain.Permission{}
+	for _, p := range pSet.ToSlice() {
+		if !dSet.Exist(p) {
+			pers = append(pers, p)
+		}
+	}
+	return pers, nil
+}
+
+func (g *GraphInfra) SearchVertices(c context.Context, start domain.Vertex,
+	isU bool, searchCond domain.SearchCond, collectCond domain.CollectCond,
+	maxDepth int) ([]domain.Vertex, error) {
+	edges, err := g.reach(c, start, isU, true, searchCond, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	vSet := set.NewSet[domain.Vertex]()
+	for _, edge := range edges {
+		if !collectCond.ShouldCollect(edge, isU) {
+			continue
+		}
+		if isU {
+			vSet.Add(domain.Vertex{Ns: edge.VNs, Name: edge.VName})
+		} else {
+			vSet.Add(domain.Vertex{Ns: edge.UNs, Name: edge.UName})
+		}
+	}
+	return vSet.ToSlice(), nil
+}
+
+// reach returns the edges in effect leaving (isSbj) or reaching the vertices
+// the BFS of graph.GraphInfra expands from start within maxDepth levels. The
+// first $graphLookup finds those vertices, walking the edges searchCond does
+// not stop at and, unless walkDenies is set, which are not denies; the second
+// one returns their edges.
+func (g *GraphInfra) reach(c context.Context, start domain.Vertex, isSbj bool,
+	walkDenies bool, searchCond domain.SearchCond, maxDepth int) (
+	[]domain.Edge, error) {
+	from, to := "u", "v"
+	if isSbj {
+		from, to = "v", "u"
+	}
+	now := time.Now()
+	inEffect := bson.D{
+		{Key: "tenant", Value: domain.TenantFrom(c)},
+		{Key: "not_before", Value: bson.M{"$not": bson.M{"$gt": now}}},
+		{Key: "expires_at", Value: bson.M{"$not": bson.M{"$lte": now}}},
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$documents", Value: bson.A{bson.M{"start": start}}}},
+	}
+	reached := bson.A{bson.A{"$start"}}
+	// the first level only expands start
+	if maxDepth > 1 {
+		walked := append(bson.D{}, inEffect...)
+		pipeline = append(pipeline, bson.D{{Key: "$graphLookup", Value: bson.D{
+			{Key: "from", Value: g.repo.collection},
+			{Key: "startWith", Value: "$start"},
+			{Key: "connectFromField", Value: from},
+			{Key: "connectToField", Value: to},
+			{Key: "as", Value: "walked"},
+			// the edges leaving the vertices of the levels before the last
+			{Key: "maxDepth", Value: maxDepth - 2},
+			{Key: "restrictSearchWithMatch",
+				Value: append(walked, walkable(isSbj, walkDenies, searchCond)...)},
+		}}})
+		reached = append(reached, "$walked."+from)
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$project", Value: bson.M{
+			"reached": bson.M{"$concatArrays": reached},
+		}}},
+		bson.D{{Key: "$graphLookup", Value: bson.D{
+			{Key: "from", Value: g.repo.collection},
+			{Key: "startWith", Value: "$reached"},
+			{Key: "connectFromField", Value: from},
+			{Key: "connectToField", Value: to},
+			{Key: "as", Value: "edges"},
+			{Key: "maxDepth", Value: 0},
+			{Key: "restrictSearchWithMatch", Value: inEffect},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$edges"}},
+		bson.D{{Key: "$replaceWith", Value: "$edges"}},
+	)
+
+	db := g.repo.client.Database(g.repo.db)
+	edges := []domain.Edge{}
+	err := g.repo.session(c, func(sc mongo.SessionContext) error {
+		cursor, err := db.Aggregate(sc, pipeline)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(sc)
+		return cursor.All(sc, &edges)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
+
+// walkable matches the edges the traversal continues past, as
+// searchCond.ShouldStop does not stop at them.
+func walkable(isSbj bool, walkDenies bool,
+	searchCond domain.SearchCond) bson.D {
+	nsKey, nameKey := "u_ns", "u_name"
+	if isSbj {
+		nsKey, nameKey = "v_ns", "v_name"
+	}
+	in := searchCond.In
+	attrs := bson.A{}
+	if len(in.Nses) > 0 {
+		attrs = append(attrs, bson.D{{Key: nsKey,
+			Value: bson.M{"$in": in.Nses}}})
+	}
+	if len(in.Names) > 0 {
+		attrs = append(attrs, bson.D{{Key: nameKey,
+			Value: bson.M{"$in": in.Names}}})
+	}
+	if len(in.Rels) > 0 {
+		attrs = append(attrs, bson.D{{Key: "rel",
+			Value: bson.M{"$in": in.Rels}}})
+	}
+	match := bson.D{}
+	if len(attrs) > 0 {
+		op := "$or"
+		if in.All {
+			op = "$and"
+		}
+		match = append(match, bson.E{Key: op, Value: attrs})
+	}
+	if !walkDenies {
+		match = append(match, bson.E{Key: "rel", Value: bson.M{
+			"$not": primitive.Regex{
+				Pattern: "^" + regexp.QuoteMeta(domain.DenyPrefix)}}})
+	}
+	return match
+}
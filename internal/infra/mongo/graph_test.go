@@ -0,0 +1,44 @@
+package mongo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph/graphtest"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/mongo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGraphInfraConformance runs against the MongoDB of RBAC_TEST_MONGO_URI,
+// which it clears, e.g. mongodb://localhost:27017/?directConnection=true
+func TestGraphInfraConformance(t *testing.T) {
+	uri := os.Getenv("RBAC_TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("RBAC_TEST_MONGO_URI is not set")
+	}
+	viper.Set("mongo.uri", uri)
+	viper.Set("mongo.db", "rbac-server-test")
+	viper.Set("mongo.collection", "edges")
+	viper.Set("mongo.connect_timeout", 10*time.Second)
+	client, disconnect, err := mongo.InitDb()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer disconnect()
+	repo := mongo.NewMongoRepository(client)
+
+	graphtest.Run(t, func(t *testing.T, schemaInfra domain.SchemaInfra,
+		edges []domain.Edge) domain.GraphInfra {
+		c := context.Background()
+		assert.NoError(t, repo.ClearAll(c))
+		for _, edge := range edges {
+			assert.NoError(t, repo.Create(domain.WithTenant(c, edge.Tenant),
+				edge))
+		}
+		return mongo.NewGraphInfra(repo, schemaInfra)
+	})
+}
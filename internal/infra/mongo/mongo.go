@@ -2,7 +2,9 @@ package mongo
 
 import (
 	"context"
+	"time"
 
+	errors "github.com/rotisserie/eris"
 	"github.com/skyrocketOoO/RBAC-server/domain"
 	"github.com/spf13/viper"
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,6 +18,22 @@ type MongoRepository struct {
 	collection string
 }
 
+// document is the stored form of an edge, u and v join the edges in the
+// $graphLookup of GraphInfra.
+type document struct {
+	domain.Edge `bson:",inline"`
+	U           domain.Vertex `bson:"u"`
+	V           domain.Vertex `bson:"v"`
+}
+
+func newDocument(edge domain.Edge) document {
+	return document{
+		Edge: edge,
+		U:    domain.Vertex{Ns: edge.UNs, Name: edge.UName},
+		V:    domain.Vertex{Ns: edge.VNs, Name: edge.VName},
+	}
+}
+
 func NewMongoRepository(client *mongo.Client) *MongoRepository {
 	return &MongoRepository{
 		client:     client,
@@ -31,37 +49,24 @@ func (r *MongoRepository) Ping(c context.Context) error {
 func (r *MongoRepository) Get(c context.Context, filter domain.Edge, queryMode bool) (
 	[]domain.Edge, error) {
 	col := r.client.Database(r.db).Collection(r.collection)
+	filter.Tenant = domain.TenantFrom(c)
 	edges := []domain.Edge{}
-	if queryMode {
-		if filter == (domain.Edge{}) {
-			// get all records
-			cursor, err := col.Find(c, bson.D{})
-			if err != nil {
-				return nil, err
-			}
-			defer cursor.Close(c)
-			if err := cursor.All(c, &edges); err != nil {
-				return nil, err
-			}
-		} else {
-			cursor, err := col.Find(c, rmZeroVal(filter))
-			if err != nil {
-				return nil, err
-			}
-			defer cursor.Close(c)
-			if err := cursor.All(c, &edges); err != nil {
-				return nil, err
-			}
+	err := r.session(c, func(sc mongo.SessionContext) error {
+		query := rmZeroVal(filter)
+		if !queryMode {
+			query = keyFilter(filter)
 		}
-	} else {
-		cursor, err := col.Find(c, filter)
+		cursor, err := col.Find(sc, query)
 		if err != nil {
-			return nil, err
-		}
-		defer cursor.Close(c)
-		if err := cursor.All(c, &edges); err != nil {
-			return nil, err
+			return err
 		}
+		defer cursor.Close(sc)
+		return cursor.All(sc, &edges)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !queryMode {
 		if len(edges) == 0 {
 			return nil, domain.ErrRecordNotFound
 		} else if len(edges) > 1 {
@@ -71,25 +76,124 @@ func (r *MongoRepository) Get(c context.Context, filter domain.Edge, queryMode b
 	return edges, nil
 }
 
+// GetNeighbors matches the vertices of each namespace with $in, which the
+// tenant_u_index or tenant_v_index serves.
+func (r *MongoRepository) GetNeighbors(c context.Context,
+	vertices []domain.Vertex, isSbj bool) ([]domain.Edge, error) {
+	edges := []domain.Edge{}
+	if len(vertices) == 0 {
+		return edges, nil
+	}
+	nsKey, nameKey := "v_ns", "v_name"
+	if isSbj {
+		nsKey, nameKey = "u_ns", "u_name"
+	}
+	namesOf := map[string][]string{}
+	nses := []string{}
+	for _, v := range vertices {
+		if _, ok := namesOf[v.Ns]; !ok {
+			nses = append(nses, v.Ns)
+		}
+		namesOf[v.Ns] = append(namesOf[v.Ns], v.Name)
+	}
+	or := bson.A{}
+	for _, ns := range nses {
+		or = append(or, bson.M{nsKey: ns, nameKey: bson.M{"$in": namesOf[ns]}})
+	}
+
+	col := r.client.Database(r.db).Collection(r.collection)
+	err := r.session(c, func(sc mongo.SessionContext) error {
+		cursor, err := col.Find(sc, bson.M{
+			"tenant": domain.TenantFrom(c),
+			"$or":    or,
+		})
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(sc)
+		return cursor.All(sc, &edges)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
+
 func (r *MongoRepository) Create(c context.Context, edge domain.Edge) error {
 	col := r.client.Database(r.db).Collection(r.collection)
-	_, err := col.InsertOne(c, edge)
+	edge.Tenant = domain.TenantFrom(c)
+	err := r.session(c, func(sc mongo.SessionContext) error {
+		_, err := col.InsertOne(sc, newDocument(edge))
+		return err
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateRecord
+	}
 	return err
 }
 
 func (r *MongoRepository) Delete(c context.Context, edge domain.Edge,
 	queryMode bool) error {
 	col := r.client.Database(r.db).Collection(r.collection)
-	if queryMode {
-		_, err := col.DeleteMany(c, rmZeroVal(edge))
-		return err
-	} else {
-		if _, err := r.Get(c, edge, false); err != nil {
+	edge.Tenant = domain.TenantFrom(c)
+	return r.session(c, func(sc mongo.SessionContext) error {
+		if queryMode {
+			_, err := col.DeleteMany(sc, rmZeroVal(edge))
+			return err
+		}
+		if _, err := r.Get(sc, edge, false); err != nil {
 			return err
 		}
-		_, err := col.DeleteOne(c, edge)
+		_, err := col.DeleteOne(sc, keyFilter(edge))
 		return err
+	})
+}
+
+// ApplyOperations runs the operations inside a multi-document transaction,
+// which requires MongoDB to be deployed as a replica set.
+func (r *MongoRepository) ApplyOperations(c context.Context,
+	operations []domain.Operation) error {
+	return r.session(c, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (
+			interface{}, error) {
+			for _, op := range operations {
+				switch op.Type {
+				case domain.CreateOperation:
+					if err := r.Create(sc, op.Edge); err != nil {
+						return nil, err
+					}
+				case domain.DeleteOperation:
+					if err := r.Delete(sc, op.Edge, false); err != nil {
+						return nil, err
+					}
+				case domain.CreateIfNotExistOperation:
+					_, err := r.Get(sc, op.Edge, false)
+					if errors.Is(err, domain.ErrRecordNotFound) {
+						err = r.Create(sc, op.Edge)
+					} else if errors.Is(err, domain.ErrDuplicateRecord) {
+						err = nil
+					}
+					if err != nil {
+						return nil, err
+					}
+				default:
+					return nil, domain.ErrBodyAttribute
+				}
+			}
+			return nil, nil
+		})
+		return err
+	})
+}
+
+func (r *MongoRepository) DeleteExpired(c context.Context, now time.Time) (
+	int64, error) {
+	col := r.client.Database(r.db).Collection(r.collection)
+	res, err := col.DeleteMany(c, bson.M{"expires_at": bson.M{"$lte": now}})
+	if err != nil {
+		return 0, err
 	}
+	return res.DeletedCount, nil
 }
 
 func (r *MongoRepository) ClearAll(c context.Context) error {
@@ -98,8 +202,9 @@ func (r *MongoRepository) ClearAll(c context.Context) error {
 	return err
 }
 
+// rmZeroVal matches the non-zero fields of the filter and its tenant.
 func rmZeroVal(filter domain.Edge) bson.M {
-	m := bson.M{}
+	m := bson.M{"tenant": filter.Tenant}
 	if filter.VNs != "" {
 		m["v_ns"] = filter.VNs
 	}
@@ -117,3 +222,15 @@ func rmZeroVal(filter domain.Edge) bson.M {
 	}
 	return m
 }
+
+// keyFilter matches the edge by every identifying field, zero values included.
+func keyFilter(edge domain.Edge) bson.M {
+	return bson.M{
+		"tenant": edge.Tenant,
+		"u_ns":   edge.UNs,
+		"u_name": edge.UName,
+		"rel":    edge.Rel,
+		"v_ns":   edge.VNs,
+		"v_name": edge.VName,
+	}
+}
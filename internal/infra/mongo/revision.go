@@ -0,0 +1,98 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// session runs fn in the causally consistent session of the request, so that
+// its reads observe the revision the context asks for at least as well as
+// the writes of the request before, and reports the revision fn reached. The
+// revisions are cluster operation times, read and written with the majority
+// concern so that no member can answer from before them or roll them back.
+// Inside a transaction fn runs in the session of the transaction.
+func (r *MongoRepository) session(c context.Context,
+	fn func(sc mongo.SessionContext) error) error {
+	if sc, ok := c.(mongo.SessionContext); ok {
+		return fn(sc)
+	}
+	rev := domain.RevisionFrom(c)
+	s, release, err := rev.Session(func() (domain.Session, error) {
+		return r.startSession(rev.AtLeast)
+	})
+	if err != nil {
+		return err
+	}
+	defer release()
+	session := s.(requestSession).Session
+
+	if err := mongo.WithSession(c, session, fn); err != nil {
+		return err
+	}
+	if ts := session.OperationTime(); ts != nil {
+		rev.Reach(formatRevision(*ts))
+	}
+	return nil
+}
+
+// requestSession is the domain.Session of the Mongo repositories.
+type requestSession struct {
+	mongo.Session
+}
+
+func (s requestSession) End() {
+	s.EndSession(context.Background())
+}
+
+func (r *MongoRepository) startSession(atLeast string) (requestSession,
+	error) {
+	session, err := r.client.StartSession(options.Session().
+		SetCausalConsistency(true).
+		SetDefaultReadConcern(readconcern.Majority()).
+		SetDefaultWriteConcern(writeconcern.Majority()))
+	if err != nil {
+		return requestSession{}, err
+	}
+	if atLeast != "" {
+		ts, err := parseRevision(atLeast)
+		if err == nil {
+			err = session.AdvanceOperationTime(&ts)
+		}
+		if err != nil {
+			session.EndSession(context.Background())
+			return requestSession{}, err
+		}
+	}
+	return requestSession{Session: session}, nil
+}
+
+func formatRevision(ts primitive.Timestamp) string {
+	return strconv.FormatUint(uint64(ts.T), 10) + "." +
+		strconv.FormatUint(uint64(ts.I), 10)
+}
+
+func parseRevision(token string) (primitive.Timestamp, error) {
+	invalid := errors.Wrap(domain.ErrBodyAttribute, "revision: invalid")
+	t, i, ok := strings.Cut(token, ".")
+	if !ok {
+		return primitive.Timestamp{}, invalid
+	}
+	tv, err := strconv.ParseUint(t, 10, 32)
+	if err != nil {
+		return primitive.Timestamp{}, invalid
+	}
+	iv, err := strconv.ParseUint(i, 10, 32)
+	if err != nil {
+		return primitive.Timestamp{}, invalid
+	}
+	return primitive.Timestamp{T: uint32(tv), I: uint32(iv)}, nil
+}
@@ -0,0 +1,105 @@
+package mongo
+
+import (
+	"context"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// the server codes of the resume tokens it cannot resume from
+const (
+	invalidResumeTokenCode  = 260
+	changeStreamHistoryLost = 286
+)
+
+type changeEvent struct {
+	Id            bson.Raw     `bson:"_id"`
+	OperationType string       `bson:"operationType"`
+	FullDocument  *domain.Edge `bson:"fullDocument"`
+	BeforeChange  *domain.Edge `bson:"fullDocumentBeforeChange"`
+}
+
+// Watch follows a change stream of the collection, which requires MongoDB to
+// be deployed as a replica set. The deleted edges are read from the
+// pre-images enabled by InitDb, the tokens are the resume tokens of the
+// stream.
+func (r *MongoRepository) Watch(c context.Context, token string,
+	send func(domain.Change) error) error {
+	col := r.client.Database(r.db).Collection(r.collection)
+	tenant := domain.TenantFrom(c)
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"$or": bson.A{
+		bson.M{"operationType": "insert", "fullDocument.tenant": tenant},
+		bson.M{"operationType": "delete",
+			"fullDocumentBeforeChange.tenant": tenant},
+	}}}}}
+	opts := options.ChangeStream().
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+	if token != "" {
+		opts.SetStartAfter(bson.M{"_data": token})
+	}
+
+	stream, err := col.Watch(c, pipeline, opts)
+	if err != nil {
+		return watchError(c, err)
+	}
+	defer stream.Close(context.Background())
+
+	checkpoint := token
+	if t := resumeToken(stream.ResumeToken()); t != "" {
+		checkpoint = t
+	}
+	if err := send(domain.Change{
+		Token: checkpoint,
+		Type:  domain.CheckpointChange,
+	}); err != nil {
+		return err
+	}
+
+	for stream.Next(c) {
+		var event changeEvent
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+		change := domain.Change{Token: resumeToken(event.Id)}
+		switch event.OperationType {
+		case "insert":
+			change.Type, change.Edge = domain.CreateChange, event.FullDocument
+		case "delete":
+			change.Type, change.Edge = domain.DeleteChange, event.BeforeChange
+		default:
+			continue
+		}
+		if err := send(change); err != nil {
+			return err
+		}
+	}
+	return watchError(c, stream.Err())
+}
+
+func resumeToken(raw bson.Raw) string {
+	if raw == nil {
+		return ""
+	}
+	data, _ := raw.Lookup("_data").StringValueOK()
+	return data
+}
+
+func watchError(c context.Context, err error) error {
+	if c.Err() != nil {
+		return c.Err()
+	}
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		switch {
+		case serverErr.HasErrorCode(changeStreamHistoryLost):
+			return domain.ErrTokenExpired
+		case serverErr.HasErrorCode(invalidResumeTokenCode):
+			return errors.Wrap(domain.ErrBodyAttribute, "token: invalid")
+		}
+	}
+	return err
+}
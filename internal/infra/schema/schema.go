@@ -0,0 +1,168 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"gopkg.in/yaml.v3"
+)
+
+type relationKey struct {
+	ns  string
+	rel string
+}
+
+// SchemaInfra keeps the namespace schema of every tenant in memory. The
+// tenants which did not set one get the default schema, the one of the
+// schema file.
+type SchemaInfra struct {
+	mu       sync.RWMutex
+	fallback *tenantSchema
+	tenants  map[string]*tenantSchema
+}
+
+type tenantSchema struct {
+	schema     domain.Schema
+	namespaces map[string]domain.NamespaceConfig
+	relations  map[relationKey]domain.RelationConfig
+}
+
+func NewSchemaInfra() *SchemaInfra {
+	return &SchemaInfra{
+		fallback: &tenantSchema{
+			schema:     domain.Schema{Namespaces: []domain.NamespaceConfig{}},
+			namespaces: map[string]domain.NamespaceConfig{},
+			relations:  map[relationKey]domain.RelationConfig{},
+		},
+		tenants: map[string]*tenantSchema{},
+	}
+}
+
+// LoadFile sets the default schema from a yaml or json file.
+func (s *SchemaInfra) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "read schema file")
+	}
+	var schema domain.Schema
+	// yaml is a superset of json
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return errors.Wrap(err, "parse schema file")
+	}
+	ts, err := index(schema)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fallback = ts
+	return nil
+}
+
+// get returns the schema of the tenant of c.
+func (s *SchemaInfra) get(c context.Context) *tenantSchema {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if ts, ok := s.tenants[domain.TenantFrom(c)]; ok {
+		return ts
+	}
+	return s.fallback
+}
+
+func (s *SchemaInfra) GetSchema(c context.Context) domain.Schema {
+	return s.get(c).schema
+}
+
+func (s *SchemaInfra) SetSchema(c context.Context, schema domain.Schema) error {
+	ts, err := index(schema)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tenants[domain.TenantFrom(c)] = ts
+	return nil
+}
+
+func (s *SchemaInfra) Namespace(c context.Context, ns string) (
+	domain.NamespaceConfig, bool) {
+	config, ok := s.get(c).namespaces[ns]
+	return config, ok
+}
+
+func (s *SchemaInfra) Relation(c context.Context, ns string, rel string) (
+	domain.RelationConfig, bool) {
+	config, ok := s.get(c).relations[relationKey{ns: ns, rel: rel}]
+	return config, ok
+}
+
+// index validates the schema and indexes its namespaces and relations.
+func index(schema domain.Schema) (*tenantSchema, error) {
+	invalid := func(format string, a ...any) error {
+		return errors.Wrap(domain.ErrBodyAttribute, fmt.Sprintf(format, a...))
+	}
+
+	namespaces := map[string]domain.NamespaceConfig{}
+	relations := map[relationKey]domain.RelationConfig{}
+	for _, ns := range schema.Namespaces {
+		if ns.Name == "" {
+			return nil, invalid("namespaces.name: missing")
+		}
+		if ns.Name == "role" || ns.Name == "user" {
+			return nil, invalid("namespaces.name: %q is reserved", ns.Name)
+		}
+		if _, ok := namespaces[ns.Name]; ok {
+			return nil, invalid("namespaces.name: %q declared twice",
+				ns.Name)
+		}
+		namespaces[ns.Name] = ns
+		for _, rel := range ns.Relations {
+			key := relationKey{ns: ns.Name, rel: rel.Name}
+			if rel.Name == "" {
+				return nil, invalid("%s.relations.name: missing", ns.Name)
+			}
+			if rel.Name == "member" || rel.Name == "parent" ||
+				strings.HasPrefix(rel.Name, domain.DenyPrefix) {
+				return nil, invalid("%s.relations.name: %q is reserved",
+					ns.Name, rel.Name)
+			}
+			if _, ok := relations[key]; ok {
+				return nil, invalid("%s.relations.name: %q declared twice",
+					ns.Name, rel.Name)
+			}
+			relations[key] = rel
+		}
+	}
+
+	for key, rel := range relations {
+		for _, computed := range rel.ComputedUsersets {
+			if _, ok := relations[relationKey{ns: key.ns, rel: computed}]; !ok {
+				return nil, invalid(
+					"%s.%s.computed_usersets: relation %q is not declared",
+					key.ns, key.rel, computed)
+			}
+		}
+		for _, ttu := range rel.TupleToUsersets {
+			if ttu.Tupleset == "" || ttu.ComputedUserset == "" {
+				return nil, invalid(
+					"%s.%s.tuple_to_usersets: tupleset and computed_userset "+
+						"are required", key.ns, key.rel)
+			}
+		}
+	}
+	return &tenantSchema{
+		schema:     schema,
+		namespaces: namespaces,
+		relations:  relations,
+	}, nil
+}
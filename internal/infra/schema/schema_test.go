@@ -0,0 +1,95 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFile(t *testing.T) {
+	c := context.Background()
+	s := schema.NewSchemaInfra()
+	assert.NoError(t, s.LoadFile("../../../config/schema.example.yaml"))
+
+	viewer, ok := s.Relation(c, "doc", "viewer")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"editor"}, viewer.ComputedUsersets)
+	assert.Equal(t, []domain.TupleToUserset{
+		{Tupleset: "folder", ComputedUserset: "viewer"},
+	}, viewer.TupleToUsersets)
+
+	_, ok = s.Relation(c, "doc", "owner")
+	assert.False(t, ok)
+}
+
+func TestSetSchemaValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema domain.Schema
+	}{
+		{"missing namespace name", domain.Schema{
+			Namespaces: []domain.NamespaceConfig{{}},
+		}},
+		{"duplicated relation", domain.Schema{
+			Namespaces: []domain.NamespaceConfig{{
+				Name: "doc",
+				Relations: []domain.RelationConfig{
+					{Name: "viewer"}, {Name: "viewer"},
+				},
+			}},
+		}},
+		{"reserved namespace", domain.Schema{
+			Namespaces: []domain.NamespaceConfig{{Name: "role"}},
+		}},
+		{"reserved relation", domain.Schema{
+			Namespaces: []domain.NamespaceConfig{{
+				Name:      "doc",
+				Relations: []domain.RelationConfig{{Name: "parent"}},
+			}},
+		}},
+		{"undeclared computed userset", domain.Schema{
+			Namespaces: []domain.NamespaceConfig{{
+				Name: "doc",
+				Relations: []domain.RelationConfig{
+					{Name: "viewer", ComputedUsersets: []string{"editor"}},
+				},
+			}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := context.Background()
+			s := schema.NewSchemaInfra()
+			assert.ErrorIs(t, s.SetSchema(c, tt.schema),
+				domain.ErrBodyAttribute)
+			assert.Empty(t, s.GetSchema(c).Namespaces)
+		})
+	}
+}
+
+func TestSchemaPerTenant(t *testing.T) {
+	acme := domain.WithTenant(context.Background(), "acme")
+	globex := domain.WithTenant(context.Background(), "globex")
+	s := schema.NewSchemaInfra()
+	assert.NoError(t, s.LoadFile("../../../config/schema.example.yaml"))
+	assert.NoError(t, s.SetSchema(acme, domain.Schema{
+		Namespaces: []domain.NamespaceConfig{{
+			Name:      "repo",
+			Relations: []domain.RelationConfig{{Name: "push"}},
+		}},
+	}))
+
+	_, ok := s.Relation(acme, "repo", "push")
+	assert.True(t, ok)
+	_, ok = s.Relation(acme, "doc", "viewer")
+	assert.False(t, ok)
+	// the other tenants keep the schema of the file
+	_, ok = s.Relation(globex, "repo", "push")
+	assert.False(t, ok)
+	_, ok = s.Relation(globex, "doc", "viewer")
+	assert.True(t, ok)
+	assert.Equal(t, s.GetSchema(context.Background()), s.GetSchema(globex))
+}
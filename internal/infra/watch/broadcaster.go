@@ -0,0 +1,131 @@
+package watch
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// Broadcaster fans the changes of a repository out to its watchers. It keeps
+// the last changes in memory so that watchers can resume, the tokens are
+// sequence numbers which do not survive a restart.
+type Broadcaster struct {
+	mu   sync.Mutex
+	seq  uint64
+	size int
+	log  []entry
+	// closed and replaced on every publish to wake the watchers up
+	notify chan struct{}
+}
+
+type entry struct {
+	seq    uint64
+	change domain.Change
+}
+
+// NewBroadcaster returns a Broadcaster retaining the last size changes.
+func NewBroadcaster(size int) *Broadcaster {
+	return &Broadcaster{size: size, notify: make(chan struct{})}
+}
+
+// Publish assigns the changes their tokens in order, the caller keeps them
+// in the order they were applied.
+func (b *Broadcaster) Publish(changes ...domain.Change) {
+	if len(changes) == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, change := range changes {
+		b.seq++
+		change.Token = strconv.FormatUint(b.seq, 10)
+		b.log = append(b.log, entry{seq: b.seq, change: change})
+	}
+	if len(b.log) > b.size {
+		b.log = append([]entry(nil), b.log[len(b.log)-b.size:]...)
+	}
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// Token returns the token of the last published change.
+func (b *Broadcaster) Token() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strconv.FormatUint(b.seq, 10)
+}
+
+// Watch implements domain.DbRepository.Watch for the tenant of c.
+func (b *Broadcaster) Watch(c context.Context, token string,
+	send func(domain.Change) error) error {
+	tenant := domain.TenantFrom(c)
+
+	b.mu.Lock()
+	next := b.seq + 1
+	if token != "" {
+		seq, err := strconv.ParseUint(token, 10, 64)
+		if err != nil {
+			b.mu.Unlock()
+			return errors.Wrap(domain.ErrBodyAttribute, "token: invalid")
+		}
+		next = seq + 1
+	}
+	b.mu.Unlock()
+	if _, _, err := b.since(next); err != nil {
+		return err
+	}
+	if err := send(domain.Change{
+		Token: strconv.FormatUint(next-1, 10),
+		Type:  domain.CheckpointChange,
+	}); err != nil {
+		return err
+	}
+
+	for {
+		entries, wait, err := b.since(next)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			next = e.seq + 1
+			if e.change.Edge.Tenant != tenant {
+				continue
+			}
+			if err := send(e.change); err != nil {
+				return err
+			}
+		}
+		if len(entries) > 0 {
+			continue
+		}
+		select {
+		case <-wait:
+		case <-c.Done():
+			return c.Err()
+		}
+	}
+}
+
+// since returns the retained entries from seq next on, and the channel
+// closed by the next publish when there are none.
+func (b *Broadcaster) since(next uint64) ([]entry, chan struct{}, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if next > b.seq+1 {
+		// a token of an earlier process
+		return nil, nil, domain.ErrTokenExpired
+	}
+	if next == b.seq+1 {
+		return nil, b.notify, nil
+	}
+	first := b.seq + 1 - uint64(len(b.log))
+	if next < first {
+		return nil, nil, domain.ErrTokenExpired
+	}
+	return append([]entry(nil), b.log[next-first:]...), b.notify, nil
+}
@@ -0,0 +1,59 @@
+package watch_test
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/watch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := watch.NewBroadcaster(2)
+	c := context.Background()
+	stop := errors.New("stop")
+	publish := func(name string) {
+		b.Publish(domain.Change{Type: domain.CreateChange,
+			Edge: &domain.Edge{UNs: "user", UName: name}})
+	}
+	names := func(token string) ([]string, error) {
+		res := []string{}
+		err := b.Watch(c, token, func(change domain.Change) error {
+			if change.Type == domain.CheckpointChange {
+				return nil
+			}
+			res = append(res, change.Edge.UName+"@"+change.Token)
+			if len(res) == 2 {
+				return stop
+			}
+			return nil
+		})
+		return res, err
+	}
+
+	publish("alice")
+	publish("bob")
+	res, err := names("0")
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"alice@1", "bob@2"}, res)
+
+	// only the last two changes are retained
+	publish("carol")
+	_, err = names("0")
+	assert.ErrorIs(t, err, domain.ErrTokenExpired)
+	res, err = names("1")
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"bob@2", "carol@3"}, res)
+
+	// a watcher waiting for changes is woken up by the next publish
+	done := make(chan []string)
+	go func() {
+		res, _ := names("3")
+		done <- res
+	}()
+	publish("dave")
+	publish("erin")
+	assert.Equal(t, []string{"dave@4", "erin@5"}, <-done)
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// RunReaper purges expired edges every interval until c is done. Traversals
+// already ignore expired edges, the reaper only keeps the repository small.
+func RunReaper(c context.Context, dbRepo domain.DbRepository,
+	interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := dbRepo.DeleteExpired(c, now)
+			if err != nil {
+				log.Error().Err(err).Msg("purge expired edges")
+				continue
+			}
+			if deleted > 0 {
+				log.Info().Int64("deleted", deleted).Msg("purged expired edges")
+			}
+		}
+	}
+}
@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"math"
+	"sync"
 
+	errors "github.com/rotisserie/eris"
 	"github.com/skyrocketOoO/RBAC-server/domain"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -12,26 +14,35 @@ type Usecase struct {
 	mongoClient *mongo.Client
 	graphInfra  domain.GraphInfra
 	dbRepo      domain.DbRepository
+	schemaInfra domain.SchemaInfra
+	// held from the cycle check of parent edges to their write, so that two
+	// writes of this server cannot close a cycle together
+	inheritMu sync.Mutex
 }
 
 func NewUsecase(mongoCli *mongo.Client, graphInfra domain.GraphInfra,
-	dbRepo domain.DbRepository) *Usecase {
+	dbRepo domain.DbRepository, schemaInfra domain.SchemaInfra) *Usecase {
 	return &Usecase{
 		mongoClient: mongoCli,
 		graphInfra:  graphInfra,
+		dbRepo:      dbRepo,
+		schemaInfra: schemaInfra,
 	}
 }
 
 func (u *Usecase) Healthy(c context.Context) error {
 	// do something check like db connection is established
 	if err := u.dbRepo.Ping(c); err != nil {
-		return err
+		return errors.Wrap(domain.ErrUnavailable, err.Error())
 	}
 
 	return nil
 }
 
 func (u *Usecase) DeleteUser(c context.Context, name string) error {
+	if err := validateIdentifier("name", name, false); err != nil {
+		return err
+	}
 	return u.dbRepo.Delete(c, domain.Edge{UNs: "user", UName: name}, true)
 }
 
@@ -65,6 +76,8 @@ func (u *Usecase) UserGetRoles(c context.Context, name string) (
 		domain.SearchCond{
 			In: domain.Compare{
 				Nses: []string{"role"},
+				Rels: []string{"member", "parent"},
+				All:  true,
 			},
 		},
 		domain.CollectCond{
@@ -100,51 +113,90 @@ func (u *Usecase) UserCheck(c context.Context, username string, objNs string,
 	)
 }
 
+func (u *Usecase) UserExplain(c context.Context, username string, objNs string,
+	relation string, objName string, maxDepth int) (*domain.Explanation, error) {
+	return u.graphInfra.Explain(
+		c,
+		domain.Vertex{
+			Ns:   "user",
+			Name: username,
+		},
+		domain.Vertex{
+			Ns:   objNs,
+			Name: objName,
+		},
+		relation,
+		domain.SearchCond{},
+		maxDepth,
+	)
+}
+
+func (u *Usecase) BulkCheck(c context.Context, checks []domain.CheckRequest) (
+	[]domain.CheckResult, error) {
+	oks, err := u.graphInfra.BulkCheck(c, checks, domain.SearchCond{})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]domain.CheckResult, len(checks))
+	for i, check := range checks {
+		results[i] = domain.CheckResult{
+			CheckRequest: check,
+			Ok:           oks[i],
+		}
+	}
+	return results, nil
+}
+
 func (u *Usecase) UserAddPermission(c context.Context, username string,
-	permission domain.Permission) error {
-	return u.dbRepo.Create(c, domain.Edge{
-		UNs:   "user",
-		UName: username,
-		Rel:   permission.Rel,
-		VNs:   permission.Ns,
-		VName: permission.Name,
-	})
+	permission domain.Permission, period domain.Period, ifNotExists bool) error {
+	return u.create(c, domain.Edge{
+		UNs:    "user",
+		UName:  username,
+		Rel:    permission.Rel,
+		VNs:    permission.Ns,
+		VName:  permission.Name,
+		Period: period,
+	}, ifNotExists)
 }
 
 func (u *Usecase) UserRemovePermission(c context.Context, username string,
 	permission domain.Permission) error {
-	return u.dbRepo.Delete(c, domain.Edge{
+	return u.remove(c, domain.Edge{
 		UNs:   "user",
 		UName: username,
 		Rel:   permission.Rel,
 		VNs:   permission.Ns,
 		VName: permission.Name,
-	}, false)
+	})
 }
 
 func (u *Usecase) UserAddRole(c context.Context, username string,
-	roleName string) error {
-	return u.dbRepo.Create(c, domain.Edge{
-		UNs:   "user",
-		UName: username,
-		Rel:   "member",
-		VNs:   "role",
-		VName: roleName,
-	})
+	roleName string, period domain.Period, ifNotExists bool) error {
+	return u.create(c, domain.Edge{
+		UNs:    "user",
+		UName:  username,
+		Rel:    "member",
+		VNs:    "role",
+		VName:  roleName,
+		Period: period,
+	}, ifNotExists)
 }
 
 func (u *Usecase) UserRemoveRole(c context.Context, username string,
 	roleName string) error {
-	return u.dbRepo.Delete(c, domain.Edge{
+	return u.remove(c, domain.Edge{
 		UNs:   "user",
 		UName: username,
 		Rel:   "member",
 		VNs:   "role",
 		VName: roleName,
-	}, false)
+	})
 }
 
 func (u *Usecase) DeleteRole(c context.Context, name string) error {
+	if err := validateIdentifier("name", name, false); err != nil {
+		return err
+	}
 	err := u.dbRepo.Delete(c, domain.Edge{
 		UNs:   "role",
 		UName: name,
@@ -190,8 +242,20 @@ func (u *Usecase) RoleGetPermissions(c context.Context, name string) (
 }
 
 func (u *Usecase) RoleAddPermission(c context.Context, roleName string,
+	permission domain.Permission, period domain.Period, ifNotExists bool) error {
+	return u.create(c, domain.Edge{
+		UNs:    "role",
+		UName:  roleName,
+		Rel:    permission.Rel,
+		VNs:    permission.Ns,
+		VName:  permission.Name,
+		Period: period,
+	}, ifNotExists)
+}
+
+func (u *Usecase) RoleRemovePermission(c context.Context, roleName string,
 	permission domain.Permission) error {
-	return u.dbRepo.Create(c, domain.Edge{
+	return u.remove(c, domain.Edge{
 		UNs:   "role",
 		UName: roleName,
 		Rel:   permission.Rel,
@@ -200,37 +264,84 @@ func (u *Usecase) RoleAddPermission(c context.Context, roleName string,
 	})
 }
 
-func (u *Usecase) RoleRemovePermission(c context.Context, roleName string,
+func (u *Usecase) UserAddDeny(c context.Context, username string,
+	permission domain.Permission, period domain.Period, ifNotExists bool) error {
+	return u.createDeny(c, domain.Edge{
+		UNs:    "user",
+		UName:  username,
+		Rel:    permission.Rel,
+		VNs:    permission.Ns,
+		VName:  permission.Name,
+		Period: period,
+	}, ifNotExists)
+}
+
+func (u *Usecase) UserRemoveDeny(c context.Context, username string,
 	permission domain.Permission) error {
-	return u.dbRepo.Delete(c, domain.Edge{
+	return u.removeDeny(c, domain.Edge{
+		UNs:   "user",
+		UName: username,
+		Rel:   permission.Rel,
+		VNs:   permission.Ns,
+		VName: permission.Name,
+	})
+}
+
+func (u *Usecase) RoleAddDeny(c context.Context, roleName string,
+	permission domain.Permission, period domain.Period, ifNotExists bool) error {
+	return u.createDeny(c, domain.Edge{
+		UNs:    "role",
+		UName:  roleName,
+		Rel:    permission.Rel,
+		VNs:    permission.Ns,
+		VName:  permission.Name,
+		Period: period,
+	}, ifNotExists)
+}
+
+func (u *Usecase) RoleRemoveDeny(c context.Context, roleName string,
+	permission domain.Permission) error {
+	return u.removeDeny(c, domain.Edge{
 		UNs:   "role",
 		UName: roleName,
 		Rel:   permission.Rel,
 		VNs:   permission.Ns,
 		VName: permission.Name,
-	}, false)
+	})
 }
 
 func (u *Usecase) RoleInheritRole(c context.Context, parentName string,
-	childName string) error {
-	return u.dbRepo.Create(c, domain.Edge{
+	childName string, ifNotExists bool) error {
+	u.inheritMu.Lock()
+	defer u.inheritMu.Unlock()
+	// the new edge closes a loop if the parent is already reachable from the
+	// child through inheritance, edges out of their period included since
+	// they may take effect later
+	cyclic, err := u.inherits(c, childName, parentName, nil, nil)
+	if err != nil {
+		return err
+	}
+	if cyclic {
+		return domain.ErrGraphCycle
+	}
+	return u.create(c, domain.Edge{
 		UNs:   "role",
 		UName: parentName,
 		Rel:   "parent",
 		VNs:   "role",
 		VName: childName,
-	})
+	}, ifNotExists)
 }
 
 func (u *Usecase) RoleUnInheritRole(c context.Context, parentName string,
 	childName string) error {
-	return u.dbRepo.Delete(c, domain.Edge{
+	return u.remove(c, domain.Edge{
 		UNs:   "role",
 		UName: parentName,
 		Rel:   "parent",
 		VNs:   "role",
 		VName: childName,
-	}, false)
+	})
 }
 
 func (u *Usecase) RoleGetChildRole(c context.Context, name string) (
@@ -273,6 +384,12 @@ func (u *Usecase) RoleGetParentRole(c context.Context, name string) (
 
 func (u *Usecase) DeleteObject(c context.Context, ns string,
 	name string) error {
+	if err := validateIdentifier("ns", ns, true); err != nil {
+		return err
+	}
+	if err := validateIdentifier("name", name, false); err != nil {
+		return err
+	}
 	return u.dbRepo.Delete(c, domain.Edge{VNs: ns, VName: name}, true)
 }
 
@@ -287,7 +404,7 @@ func (u *Usecase) WhichRoleHasPermission(c context.Context, objNs string,
 		false,
 		domain.SearchCond{},
 		domain.CollectCond{
-			NotIn: domain.Compare{
+			In: domain.Compare{
 				Nses: []string{"role"},
 			},
 		},
@@ -295,11 +412,7 @@ func (u *Usecase) WhichRoleHasPermission(c context.Context, objNs string,
 	if err != nil {
 		return nil, err
 	}
-	roles := make([]string, len(vertices))
-	for i, v := range vertices {
-		roles[i] = v.Name
-	}
-	return roles, nil
+	return u.withoutDenied(c, vertices, objNs, objName)
 }
 
 func (u *Usecase) WhichUserHasPermission(c context.Context, objNs string,
@@ -313,7 +426,7 @@ func (u *Usecase) WhichUserHasPermission(c context.Context, objNs string,
 		false,
 		domain.SearchCond{},
 		domain.CollectCond{
-			NotIn: domain.Compare{
+			In: domain.Compare{
 				Nses: []string{"user"},
 			},
 		},
@@ -321,11 +434,258 @@ func (u *Usecase) WhichUserHasPermission(c context.Context, objNs string,
 	if err != nil {
 		return nil, err
 	}
-	users := make([]string, len(vertices))
-	for i, v := range vertices {
-		users[i] = v.Name
+	return u.withoutDenied(c, vertices, objNs, objName)
+}
+
+// withoutDenied returns the names of the vertices that keep at least one
+// relation on the object after its denies are applied.
+func (u *Usecase) withoutDenied(c context.Context, vertices []domain.Vertex,
+	objNs string, objName string) ([]string, error) {
+	// denies are edges into the object, without any there is nothing to drop
+	edges, err := u.dbRepo.Get(c, domain.Edge{VNs: objNs, VName: objName}, true)
+	if err != nil {
+		return nil, err
 	}
-	return users, nil
+	hasDeny := false
+	for _, edge := range edges {
+		if _, deny := domain.DeniedRel(edge.Rel); deny {
+			hasDeny = true
+			break
+		}
+	}
+
+	names := []string{}
+	for _, v := range vertices {
+		if hasDeny {
+			pers, err := u.graphInfra.SearchPermissions(
+				c,
+				v,
+				true,
+				domain.SearchCond{},
+				domain.CollectCond{
+					In: domain.Compare{
+						Nses:  []string{objNs},
+						Names: []string{objName},
+						All:   true,
+					},
+				},
+				math.MaxInt)
+			if err != nil {
+				return nil, err
+			}
+			if len(pers) == 0 {
+				continue
+			}
+		}
+		names = append(names, v.Name)
+	}
+	return names, nil
+}
+
+func (u *Usecase) FindCycles(c context.Context) ([][]domain.Vertex, error) {
+	return u.graphInfra.FindCycles(c)
+}
+
+func (u *Usecase) ApplyOperations(c context.Context,
+	operations []domain.Operation) error {
+	for i, op := range operations {
+		var err error
+		if op.Type == domain.DeleteOperation {
+			err = validateKey(op.Edge)
+		} else {
+			err = u.validate(c, op.Edge)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "operations[%d]", i)
+		}
+	}
+	u.inheritMu.Lock()
+	defer u.inheritMu.Unlock()
+	if err := u.checkBatchCycles(c, operations); err != nil {
+		return err
+	}
+	return u.dbRepo.ApplyOperations(c, operations)
+}
+
+// checkBatchCycles rejects the batches whose parent edges would close a loop
+// once applied in order, as RoleInheritRole does for a single edge.
+func (u *Usecase) checkBatchCycles(c context.Context,
+	operations []domain.Operation) error {
+	// the parent edges the batch adds and removes before each operation
+	added := map[string]map[string]bool{}
+	removed := map[string]map[string]bool{}
+	set := func(edges map[string]map[string]bool, parent string, child string,
+		ok bool) {
+		if edges[parent] == nil {
+			edges[parent] = map[string]bool{}
+		}
+		edges[parent][child] = ok
+	}
+	for i, op := range operations {
+		if op.Edge.Rel != "parent" || op.Edge.UNs != "role" ||
+			op.Edge.VNs != "role" {
+			continue
+		}
+		parent, child := op.Edge.UName, op.Edge.VName
+		if op.Type == domain.DeleteOperation {
+			set(added, parent, child, false)
+			set(removed, parent, child, true)
+			continue
+		}
+		cyclic, err := u.inherits(c, child, parent, added, removed)
+		if err != nil {
+			return err
+		}
+		if cyclic {
+			return errors.Wrapf(domain.ErrGraphCycle, "operations[%d]", i)
+		}
+		set(added, parent, child, true)
+		set(removed, parent, child, false)
+	}
+	return nil
+}
+
+// inherits reports whether the role to is reached from the role from through
+// the stored parent edges, whatever their period, with the added and removed
+// ones of a batch.
+func (u *Usecase) inherits(c context.Context, from string, to string,
+	added map[string]map[string]bool, removed map[string]map[string]bool) (
+	bool, error) {
+	visited := map[string]bool{from: true}
+	stack := []string{from}
+	for len(stack) > 0 {
+		role := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if role == to {
+			return true, nil
+		}
+		edges, err := u.dbRepo.Get(c, domain.Edge{
+			UNs:   "role",
+			UName: role,
+			Rel:   "parent",
+			VNs:   "role",
+		}, true)
+		if err != nil {
+			return false, err
+		}
+		children := []string{}
+		for _, edge := range edges {
+			if !removed[role][edge.VName] {
+				children = append(children, edge.VName)
+			}
+		}
+		for child, ok := range added[role] {
+			if ok {
+				children = append(children, child)
+			}
+		}
+		for _, child := range children {
+			if !visited[child] {
+				visited[child] = true
+				stack = append(stack, child)
+			}
+		}
+	}
+	return false, nil
+}
+
+func (u *Usecase) GetSchema(c context.Context) (domain.Schema, error) {
+	return u.schemaInfra.GetSchema(c), nil
+}
+
+func (u *Usecase) SetSchema(c context.Context, schema domain.Schema) error {
+	return u.schemaInfra.SetSchema(c, schema)
+}
+
+func (u *Usecase) ExportTenant(c context.Context) ([]domain.Edge, error) {
+	return u.dbRepo.Get(c, domain.Edge{}, true)
+}
+
+func (u *Usecase) PurgeTenant(c context.Context) error {
+	return u.dbRepo.Delete(c, domain.Edge{}, true)
+}
+
+func (u *Usecase) Watch(c context.Context, token string,
+	send func(domain.Change) error) error {
+	return u.dbRepo.Watch(c, token, send)
+}
+
+// CacheStats reports the cache in front of the graph, if there is one.
+func (u *Usecase) CacheStats(c context.Context) domain.CacheStats {
+	if cached, ok := u.graphInfra.(interface {
+		Stats() domain.CacheStats
+	}); ok {
+		return cached.Stats()
+	}
+	return domain.CacheStats{}
+}
+
+// RebuildClosure rebuilds the role closure in front of the graph from the
+// edges of the tenant.
+func (u *Usecase) RebuildClosure(c context.Context) error {
+	if closure, ok := u.graphInfra.(interface {
+		Rebuild(c context.Context) error
+	}); ok {
+		return closure.Rebuild(c)
+	}
+	return errors.Wrap(domain.ErrNotImplemented, "closure.enabled is not set")
+}
+
+// VerifyClosure compares the role closure in front of the graph, if there is
+// one, with the edges of the tenant.
+func (u *Usecase) VerifyClosure(c context.Context) (domain.ClosureReport,
+	error) {
+	if closure, ok := u.graphInfra.(interface {
+		Verify(c context.Context) (domain.ClosureReport, error)
+	}); ok {
+		return closure.Verify(c)
+	}
+	return domain.ClosureReport{}, nil
+}
+
+// create stores the edge, treating an existing one as success when
+// ifNotExists is set so that clients can retry writes safely.
+func (u *Usecase) create(c context.Context, edge domain.Edge,
+	ifNotExists bool) error {
+	if err := u.validate(c, edge); err != nil {
+		return err
+	}
+	return u.store(c, edge, ifNotExists)
+}
+
+// createDeny stores the deny of the grant edge once the grant is validated.
+func (u *Usecase) createDeny(c context.Context, edge domain.Edge,
+	ifNotExists bool) error {
+	if err := u.validate(c, edge); err != nil {
+		return err
+	}
+	edge.Rel = domain.DenyRel(edge.Rel)
+	return u.store(c, edge, ifNotExists)
+}
+
+func (u *Usecase) remove(c context.Context, edge domain.Edge) error {
+	if err := validateKey(edge); err != nil {
+		return err
+	}
+	return u.dbRepo.Delete(c, edge, false)
+}
+
+// removeDeny deletes the deny of the grant edge.
+func (u *Usecase) removeDeny(c context.Context, edge domain.Edge) error {
+	if err := validateKey(edge); err != nil {
+		return err
+	}
+	edge.Rel = domain.DenyRel(edge.Rel)
+	return u.dbRepo.Delete(c, edge, false)
+}
+
+func (u *Usecase) store(c context.Context, edge domain.Edge,
+	ifNotExists bool) error {
+	err := u.dbRepo.Create(c, edge)
+	if ifNotExists && errors.Is(err, domain.ErrDuplicateRecord) {
+		return nil
+	}
+	return err
 }
 
 // func (u *Usecase) DeletePermission(c context.Context, name string) {
@@ -0,0 +1,318 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skyrocketOoO/RBAC-server/domain"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
+	"github.com/skyrocketOoO/RBAC-server/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUsecase() *usecase.Usecase {
+	repo := memory.NewMemoryRepository()
+	schemaInfra := schema.NewSchemaInfra()
+	return usecase.NewUsecase(nil, graph.NewGraphInfra(repo, schemaInfra), repo,
+		schemaInfra)
+}
+
+func TestWhichUserHasPermissionWithDeny(t *testing.T) {
+	c := context.Background()
+	u := newUsecase()
+	repo := domain.Permission{Rel: "read", Ns: "repo", Name: "x"}
+	assert.NoError(t, u.RoleAddPermission(c, "engineering", repo,
+		domain.Period{}, false))
+	for _, name := range []string{"alice", "bob", "contractor"} {
+		assert.NoError(t, u.UserAddRole(c, name, "engineering", domain.Period{},
+			false))
+	}
+	assert.NoError(t, u.UserAddDeny(c, "contractor", repo, domain.Period{},
+		false))
+
+	users, err := u.WhichUserHasPermission(c, "repo", "x")
+	assert.NoError(t, err)
+	sort.Strings(users)
+	assert.Equal(t, []string{"alice", "bob"}, users)
+
+	ok, err := u.UserCheck(c, "contractor", "repo", "read", "x")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, u.UserRemoveDeny(c, "contractor", repo))
+	ok, err = u.UserCheck(c, "contractor", "repo", "read", "x")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestValidateWrites(t *testing.T) {
+	c := context.Background()
+	u := newUsecase()
+	assert.NoError(t, u.SetSchema(c, domain.Schema{
+		Namespaces: []domain.NamespaceConfig{{
+			Name: "doc",
+			Relations: []domain.RelationConfig{
+				{Name: "viewer", Subjects: []string{"user", "role"}},
+			},
+		}},
+	}))
+
+	tests := []struct {
+		name string
+		edge domain.Edge
+		ok   bool
+	}{
+		{"membership", domain.Edge{UNs: "user", UName: "alice", Rel: "member",
+			VNs: "role", VName: "admin"}, true},
+		{"misspelled membership", domain.Edge{UNs: "user", UName: "alice",
+			Rel: "membr", VNs: "role", VName: "admin"}, false},
+		{"permission on user", domain.Edge{UNs: "role", UName: "admin",
+			Rel: "read", VNs: "user", VName: "alice"}, false},
+		{"role member of role", domain.Edge{UNs: "role", UName: "admin",
+			Rel: "member", VNs: "role", VName: "ops"}, false},
+		{"declared relation", domain.Edge{UNs: "role", UName: "admin",
+			Rel: "viewer", VNs: "doc", VName: "readme"}, true},
+		{"deny", domain.Edge{UNs: "user", UName: "alice",
+			Rel: domain.DenyRel("viewer"), VNs: "doc", VName: "readme"}, false},
+		{"invalid name", domain.Edge{UNs: "user", UName: "alice:x",
+			Rel: "viewer", VNs: "doc", VName: "readme"}, false},
+		{"slash in name", domain.Edge{UNs: "user", UName: "alice",
+			Rel: "read", VNs: "repo", VName: "x/y"}, true},
+		{"slash in namespace", domain.Edge{UNs: "user", UName: "alice",
+			Rel: "read", VNs: "repo/x", VName: "y"}, false},
+		{"undeclared relation", domain.Edge{UNs: "user", UName: "alice",
+			Rel: "owner", VNs: "doc", VName: "readme"}, false},
+		{"disallowed subject", domain.Edge{UNs: "folder", UName: "root",
+			Rel: "viewer", VNs: "doc", VName: "readme"}, false},
+		{"undeclared namespace", domain.Edge{UNs: "user", UName: "alice",
+			Rel: "read", VNs: "repo", VName: "x"}, true},
+		{"missing name", domain.Edge{UNs: "user", Rel: "read", VNs: "repo",
+			VName: "x"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.ApplyOperations(c, []domain.Operation{
+				{Type: domain.CreateOperation, Edge: tt.edge},
+			})
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrBodyAttribute)
+			}
+		})
+	}
+}
+
+func TestValidateDenies(t *testing.T) {
+	c := context.Background()
+	u := newUsecase()
+	assert.NoError(t, u.SetSchema(c, domain.Schema{
+		Namespaces: []domain.NamespaceConfig{{
+			Name:      "doc",
+			Relations: []domain.RelationConfig{{Name: "viewer"}},
+		}},
+	}))
+	viewer := domain.Permission{Rel: "viewer", Ns: "doc", Name: "readme"}
+	denied := domain.Permission{Rel: domain.DenyRel("viewer"), Ns: "doc",
+		Name: "readme"}
+
+	// the deny calls validate the relation they revoke
+	assert.NoError(t, u.UserAddDeny(c, "alice", viewer, domain.Period{}, false))
+	assert.ErrorIs(t, u.UserAddDeny(c, "alice", denied, domain.Period{}, false),
+		domain.ErrBodyAttribute)
+	assert.ErrorIs(t, u.RoleAddDeny(c, "staff", domain.Permission{
+		Rel: "owner", Ns: "doc", Name: "readme"}, domain.Period{}, false),
+		domain.ErrBodyAttribute)
+
+	// and a deny is never written or removed as a plain relation
+	assert.ErrorIs(t, u.RoleAddPermission(c, "staff", denied, domain.Period{},
+		false), domain.ErrBodyAttribute)
+	assert.ErrorIs(t, u.UserRemovePermission(c, "alice", denied),
+		domain.ErrBodyAttribute)
+	assert.ErrorIs(t, u.ApplyOperations(c, []domain.Operation{{
+		Type: domain.DeleteOperation,
+		Edge: domain.Edge{UNs: "user", UName: "alice", Rel: denied.Rel,
+			VNs: "doc", VName: "readme"},
+	}}), domain.ErrBodyAttribute)
+	edges, err := u.ExportTenant(c)
+	assert.NoError(t, err)
+	assert.Len(t, edges, 1)
+
+	assert.NoError(t, u.UserRemoveDeny(c, "alice", viewer))
+	edges, err = u.ExportTenant(c)
+	assert.NoError(t, err)
+	assert.Empty(t, edges)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	u := newUsecase()
+	acme := domain.WithTenant(context.Background(), "acme")
+	globex := domain.WithTenant(context.Background(), "globex")
+	doc := domain.Permission{Rel: "read", Ns: "doc", Name: "plan"}
+	assert.NoError(t, u.RoleAddPermission(acme, "staff", doc, domain.Period{},
+		false))
+	assert.NoError(t, u.UserAddRole(globex, "alice", "staff", domain.Period{},
+		false))
+
+	// the role of globex does not reach the grant of acme
+	ok, err := u.UserCheck(globex, "alice", "doc", "read", "plan")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	users, err := u.WhichUserHasPermission(acme, "doc", "plan")
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	assert.NoError(t, u.UserAddRole(acme, "alice", "staff", domain.Period{},
+		false))
+	ok, err = u.UserCheck(acme, "alice", "doc", "read", "plan")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	assert.NoError(t, u.PurgeTenant(acme))
+	edges, err := u.ExportTenant(acme)
+	assert.NoError(t, err)
+	assert.Empty(t, edges)
+	edges, err = u.ExportTenant(globex)
+	assert.NoError(t, err)
+	assert.Len(t, edges, 1)
+
+	// the schema of acme leaves globex alone
+	assert.NoError(t, u.SetSchema(acme, domain.Schema{Strict: true}))
+	assert.ErrorIs(t, u.RoleAddPermission(acme, "staff", doc, domain.Period{},
+		false), domain.ErrBodyAttribute)
+	assert.NoError(t, u.RoleAddPermission(globex, "staff", doc,
+		domain.Period{}, false))
+	schema, err := u.GetSchema(globex)
+	assert.NoError(t, err)
+	assert.False(t, schema.Strict)
+}
+
+func TestRoleInheritRoleRejectsCycles(t *testing.T) {
+	c := context.Background()
+	u := newUsecase()
+	assert.NoError(t, u.RoleInheritRole(c, "admin", "editor", false))
+	assert.NoError(t, u.RoleInheritRole(c, "editor", "viewer", false))
+
+	tests := []struct {
+		name   string
+		parent string
+		child  string
+	}{
+		{"direct", "editor", "admin"},
+		{"indirect", "viewer", "admin"},
+		{"self-loop", "admin", "admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.RoleInheritRole(c, tt.parent, tt.child, false)
+			assert.ErrorIs(t, err, domain.ErrGraphCycle)
+		})
+	}
+
+	parents, err := u.RoleGetParentRole(c, "admin")
+	assert.NoError(t, err)
+	assert.Empty(t, parents)
+	cycles, err := u.FindCycles(c)
+	assert.NoError(t, err)
+	assert.Empty(t, cycles)
+
+	// an edge which only takes effect later still closes the cycle then
+	later := time.Now().Add(time.Hour)
+	assert.NoError(t, u.ApplyOperations(c, []domain.Operation{{
+		Type: domain.CreateOperation,
+		Edge: domain.Edge{UNs: "role", UName: "viewer", Rel: "parent",
+			VNs: "role", VName: "guest",
+			Period: domain.Period{NotBefore: &later}},
+	}}))
+	assert.ErrorIs(t, u.RoleInheritRole(c, "guest", "admin", false),
+		domain.ErrGraphCycle)
+}
+
+// slowRepository widens the window between the reads and the writes.
+type slowRepository struct {
+	domain.DbRepository
+}
+
+func (r slowRepository) Get(c context.Context, filter domain.Edge,
+	queryMode bool) ([]domain.Edge, error) {
+	edges, err := r.DbRepository.Get(c, filter, queryMode)
+	time.Sleep(time.Millisecond)
+	return edges, err
+}
+
+func TestRoleInheritRoleConcurrently(t *testing.T) {
+	c := context.Background()
+	repo := slowRepository{memory.NewMemoryRepository()}
+	schemaInfra := schema.NewSchemaInfra()
+	u := usecase.NewUsecase(nil, graph.NewGraphInfra(repo, schemaInfra), repo,
+		schemaInfra)
+	for i := 0; i < 20; i++ {
+		a, b := fmt.Sprint("a", i), fmt.Sprint("b", i)
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for j, pair := range [][2]string{{a, b}, {b, a}} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[j] = u.RoleInheritRole(c, pair[0], pair[1], false)
+			}()
+		}
+		wg.Wait()
+		// one of the two closes the cycle
+		assert.True(t, errs[0] == nil != (errs[1] == nil), "%v", errs)
+	}
+	cycles, err := u.FindCycles(c)
+	assert.NoError(t, err)
+	assert.Empty(t, cycles)
+}
+
+func TestApplyOperationsRejectsCycles(t *testing.T) {
+	c := context.Background()
+	u := newUsecase()
+	assert.NoError(t, u.RoleInheritRole(c, "admin", "editor", false))
+	inherit := func(action domain.Action, parent string,
+		child string) domain.Operation {
+		return domain.Operation{Type: action, Edge: domain.Edge{UNs: "role",
+			UName: parent, Rel: "parent", VNs: "role", VName: child}}
+	}
+
+	tests := []struct {
+		name       string
+		operations []domain.Operation
+		cyclic     bool
+	}{
+		{"self-loop", []domain.Operation{
+			inherit(domain.CreateOperation, "ops", "ops"),
+		}, true},
+		{"with a stored edge", []domain.Operation{
+			inherit(domain.CreateIfNotExistOperation, "editor", "admin"),
+		}, true},
+		{"with an earlier edge of the batch", []domain.Operation{
+			inherit(domain.CreateOperation, "editor", "viewer"),
+			inherit(domain.CreateOperation, "viewer", "admin"),
+		}, true},
+		{"after an earlier delete of the batch", []domain.Operation{
+			inherit(domain.DeleteOperation, "admin", "editor"),
+			inherit(domain.CreateOperation, "editor", "admin"),
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.ApplyOperations(c, tt.operations)
+			if tt.cyclic {
+				assert.ErrorIs(t, err, domain.ErrGraphCycle)
+			} else {
+				assert.NoError(t, err)
+			}
+			cycles, err := u.FindCycles(c)
+			assert.NoError(t, err)
+			assert.Empty(t, cycles)
+		})
+	}
+}
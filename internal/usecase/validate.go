@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/skyrocketOoO/RBAC-server/domain"
+)
+
+// validate checks a written edge against the reserved words and the
+// registered schema, the error names the offending field.
+func (u *Usecase) validate(c context.Context, edge domain.Edge) error {
+	invalid := func(format string, a ...any) error {
+		return errors.Wrap(domain.ErrBodyAttribute, fmt.Sprintf(format, a...))
+	}
+
+	if err := validateKey(edge); err != nil {
+		return err
+	}
+	if !edge.Period.Valid() {
+		return invalid("expires_at: must be after not_before")
+	}
+
+	switch {
+	case edge.VNs == "user":
+		return invalid("v_ns: namespace %q is reserved", edge.VNs)
+	case edge.Rel == "member":
+		if edge.UNs != "user" {
+			return invalid("u_ns: only user can be a member, got %q", edge.UNs)
+		}
+		if edge.VNs != "role" {
+			return invalid("v_ns: only role can have members, got %q", edge.VNs)
+		}
+		return nil
+	case edge.Rel == "parent":
+		if edge.UNs != "role" {
+			return invalid("u_ns: only role can be a parent, got %q", edge.UNs)
+		}
+		if edge.VNs != "role" {
+			return invalid("v_ns: only role can have parents, got %q", edge.VNs)
+		}
+		return nil
+	case edge.VNs == "role":
+		return invalid("rel: %q is not a relation of namespace %q",
+			edge.Rel, edge.VNs)
+	}
+
+	if _, ok := u.schemaInfra.Namespace(c, edge.VNs); !ok {
+		if u.schemaInfra.GetSchema(c).Strict {
+			return invalid("v_ns: namespace %q is not declared", edge.VNs)
+		}
+		return nil
+	}
+	config, ok := u.schemaInfra.Relation(c, edge.VNs, edge.Rel)
+	if !ok {
+		return invalid("rel: %q is not a relation of namespace %q",
+			edge.Rel, edge.VNs)
+	}
+	if len(config.Subjects) > 0 && !slices.Contains(config.Subjects, edge.UNs) {
+		return invalid("u_ns: namespace %q cannot hold %s on %s",
+			edge.UNs, edge.Rel, edge.VNs)
+	}
+	return nil
+}
+
+// validateKey checks the fields which identify an edge, for the deletes as
+// well as the writes. A deny is never named directly, the deny calls add the
+// prefix to the validated relation.
+func validateKey(edge domain.Edge) error {
+	if strings.HasPrefix(edge.Rel, domain.DenyPrefix) {
+		return errors.Wrap(domain.ErrBodyAttribute,
+			fmt.Sprintf("rel: %q is reserved", edge.Rel))
+	}
+	for _, field := range []struct {
+		name, value string
+		ns          bool
+	}{
+		{"u_ns", edge.UNs, true}, {"u_name", edge.UName, false},
+		{"rel", edge.Rel, false},
+		{"v_ns", edge.VNs, true}, {"v_name", edge.VName, false},
+	} {
+		if err := validateIdentifier(field.name, field.value,
+			field.ns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateIdentifier checks a single field against domain.Identifier, or
+// domain.NamespaceIdentifier for a namespace.
+func validateIdentifier(name string, value string, ns bool) error {
+	var msg string
+	switch {
+	case value == "":
+		msg = "missing"
+	case len(value) > domain.MaxIdentifier:
+		msg = fmt.Sprintf("longer than %d characters", domain.MaxIdentifier)
+	case ns && !domain.NamespaceIdentifier.MatchString(value):
+		msg = domain.NamespaceIdentifierMsg
+	case !domain.Identifier.MatchString(value):
+		msg = domain.IdentifierMsg
+	default:
+		return nil
+	}
+	return errors.Wrap(domain.ErrBodyAttribute, name+": "+msg)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"net"
 	"os"
 	"time"
 
@@ -11,11 +13,20 @@ import (
 	"github.com/skyrocketOoO/RBAC-server/api"
 	"github.com/skyrocketOoO/RBAC-server/config"
 	"github.com/skyrocketOoO/RBAC-server/domain"
+	grpcDelivery "github.com/skyrocketOoO/RBAC-server/internal/delivery/grpc"
+	"github.com/skyrocketOoO/RBAC-server/internal/delivery/proto"
 	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest"
 	"github.com/skyrocketOoO/RBAC-server/internal/delivery/rest/middleware"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/cache"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/closure"
 	"github.com/skyrocketOoO/RBAC-server/internal/infra/graph"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/memory"
 	"github.com/skyrocketOoO/RBAC-server/internal/infra/mongo"
+	"github.com/skyrocketOoO/RBAC-server/internal/infra/schema"
 	"github.com/skyrocketOoO/RBAC-server/internal/usecase"
+	"github.com/spf13/viper"
+	mongoDriver "go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -28,22 +39,145 @@ func main() {
 		log.Fatal().Msg(errors.ToString(err, true))
 	}
 
-	mongoClient, disconnectDb, err := mongo.InitDb()
-	if err != nil {
-		log.Fatal().Msg(errors.ToString(err, true))
+	var (
+		mongoClient *mongoDriver.Client
+		mongoRepo   *mongo.MongoRepository
+		dbRepo      domain.DbRepository
+	)
+	switch viper.GetString("db") {
+	case "memory":
+		dbRepo = memory.NewMemoryRepository()
+	default:
+		var (
+			disconnectDb func()
+			err          error
+		)
+		mongoClient, disconnectDb, err = mongo.InitDb()
+		if err != nil {
+			log.Fatal().Msg(errors.ToString(err, true))
+		}
+		defer disconnectDb()
+		mongoRepo = mongo.NewMongoRepository(mongoClient)
+		dbRepo = mongoRepo
+	}
+
+	schemaStore := schema.NewSchemaInfra()
+	if file := viper.GetString("schema.file"); file != "" {
+		if err := schemaStore.LoadFile(file); err != nil {
+			log.Fatal().Msg(errors.ToString(err, true))
+		}
 	}
-	defer disconnectDb()
+	var schemaInfra domain.SchemaInfra = schemaStore
 
-	dbRepo := mongo.NewMongoRepository(mongoClient)
+	// the cache only sees the reads of the bfs engine
+	engine := viper.GetString("graph.engine")
+	var traversalCache *cache.Cache
+	if viper.GetBool("cache.enabled") && engine == "bfs" {
+		traversalCache = cache.NewCache(viper.GetInt("cache.size"))
+		dbRepo = traversalCache.Repository(dbRepo)
+		schemaInfra = traversalCache.Schema(schemaInfra)
+	} else if viper.GetBool("cache.enabled") {
+		log.Warn().Msg("the cache is disabled by graph.engine " + engine)
+	}
+
+	var roleClosure *closure.Closure
+	if viper.GetBool("closure.enabled") {
+		roleClosure = closure.NewClosure(dbRepo)
+		dbRepo = roleClosure.Repository(dbRepo)
+	}
+
+	if interval := viper.GetDuration("reaper.interval"); interval > 0 {
+		reaperCtx, stopReaper := context.WithCancel(context.Background())
+		defer stopReaper()
+		go usecase.RunReaper(reaperCtx, dbRepo, interval)
+	}
 
 	var graphInfra domain.GraphInfra
-	graphInfra = graph.NewGraphInfra(dbRepo)
-	usecase := usecase.NewUsecase(mongoClient, graphInfra, dbRepo)
+	switch engine {
+	case "mongo":
+		graphInfra = mongo.NewGraphInfra(mongoRepo, schemaInfra)
+	default:
+		graphInfra = graph.NewGraphInfra(dbRepo, schemaInfra)
+	}
+	if traversalCache != nil {
+		graphInfra = traversalCache.Graph(graphInfra)
+	}
+	if roleClosure != nil {
+		graphInfra = roleClosure.Graph(graphInfra, schemaInfra)
+	}
+	usecase := usecase.NewUsecase(mongoClient, graphInfra, dbRepo, schemaInfra)
 	delivery := rest.NewDelivery(usecase)
 
+	authenticators, err := newAuthenticators()
+	if err != nil {
+		log.Fatal().Msg(errors.ToString(err, true))
+	}
+	if len(authenticators) == 0 {
+		log.Warn().Msg("auth is disabled, every caller of the APIs is an admin")
+	}
+
+	var authz *middleware.Authorizer
+	if viper.GetBool("authz.enabled") {
+		authz = middleware.NewAuthorizer(usecase,
+			viper.GetStringSlice("authz.super_admins"))
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcDelivery.AuthInterceptor(authenticators...),
+			grpcDelivery.TenantInterceptor,
+			grpcDelivery.AuthzInterceptor(authz),
+			grpcDelivery.RevisionInterceptor),
+		grpc.ChainStreamInterceptor(
+			grpcDelivery.AuthStreamInterceptor(authenticators...),
+			grpcDelivery.TenantStreamInterceptor))
+	proto.RegisterRbacServiceServer(grpcServer, grpcDelivery.NewDelivery(usecase))
+	lis, err := net.Listen("tcp", viper.GetString("grpc.addr"))
+	if err != nil {
+		log.Fatal().Msg(errors.ToString(errors.Wrap(err, "grpc listen"), true))
+	}
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatal().Msg(errors.ToString(errors.Wrap(err, "grpc serve"), true))
+		}
+	}()
+	defer grpcServer.GracefulStop()
+
 	router := gin.Default()
 	router.Use(middleware.CORS())
-	api.Binding(router, delivery)
+	router.Use(middleware.Auth(authenticators...))
+	api.Binding(router, delivery, authz)
 
-	router.Run(":8081")
+	router.Run(viper.GetString("server.addr"))
+}
+
+func newAuthenticators() ([]middleware.Authenticator, error) {
+	if !viper.GetBool("auth.enabled") {
+		return nil, nil
+	}
+	authenticators := []middleware.Authenticator{}
+	var keys []middleware.APIKey
+	if err := viper.UnmarshalKey("auth.api_keys", &keys); err != nil {
+		return nil, errors.Wrap(err, "auth.api_keys")
+	}
+	if len(keys) > 0 {
+		authenticators = append(authenticators,
+			middleware.NewAPIKeyAuthenticator(keys))
+	}
+	opts := middleware.TokenOptions{
+		Issuer:   viper.GetString("auth.issuer"),
+		Audience: viper.GetString("auth.audience"),
+	}
+	if secret := viper.GetString("auth.hmac.secret"); secret != "" {
+		authenticators = append(authenticators,
+			middleware.NewHMACAuthenticator([]byte(secret), opts))
+	}
+	if file := viper.GetString("auth.jwks.file"); file != "" {
+		a, err := middleware.NewJWKSAuthenticator(file, opts)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, a)
+	}
+	return authenticators, nil
 }